@@ -0,0 +1,343 @@
+package backlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ActivityType is the type of Backlog activity
+type ActivityType int
+
+// Activity types
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-recent-updates/
+const (
+	ActivityTypeIssueCreated         ActivityType = 1  // 課題の追加
+	ActivityTypeIssueUpdated         ActivityType = 2  // 課題の更新
+	ActivityTypeIssueCommented       ActivityType = 3  // 課題にコメント
+	ActivityTypeIssueDeleted         ActivityType = 4  // 課題の削除
+	ActivityTypeWikiCreated          ActivityType = 5  // Wikiを追加
+	ActivityTypeWikiUpdated          ActivityType = 6  // Wikiを更新
+	ActivityTypeWikiDeleted          ActivityType = 7  // Wikiを削除
+	ActivityTypeFileAdded            ActivityType = 8  // 共有ファイルを追加
+	ActivityTypeFileUpdated          ActivityType = 9  // 共有ファイルを更新
+	ActivityTypeFileDeleted          ActivityType = 10 // 共有ファイルを削除
+	ActivityTypeSVNCommitted         ActivityType = 11 // Subversionコミット
+	ActivityTypeGitPushed            ActivityType = 12 // GITプッシュ
+	ActivityTypeGitRepositoryCreated ActivityType = 13 // GITリポジトリ作成
+	ActivityTypeIssueMultiUpdated    ActivityType = 14 // 課題をまとめて更新
+	ActivityTypeProjectUserAdded     ActivityType = 15 // プロジェクトに参加
+	ActivityTypeProjectUserRemoved   ActivityType = 16 // プロジェクトから脱退
+	ActivityTypeNotificationAdded    ActivityType = 17 // コメントにお知らせを追加
+	ActivityTypePullRequestAdded     ActivityType = 18 // プルリクエストの追加
+	ActivityTypePullRequestUpdated   ActivityType = 19 // プルリクエストの更新
+	ActivityTypePullRequestCommented ActivityType = 20 // プルリクエストにコメント
+	ActivityTypePullRequestDeleted   ActivityType = 21 // プルリクエストの削除
+	ActivityTypeMilestoneCreated     ActivityType = 22 // マイルストーンの追加
+	ActivityTypeMilestoneUpdated     ActivityType = 23 // マイルストーンの更新
+	ActivityTypeMilestoneDeleted     ActivityType = 24 // マイルストーンの削除
+	ActivityTypeProjectGroupAdded    ActivityType = 25 // グループがプロジェクトに参加
+	ActivityTypeProjectGroupRemoved  ActivityType = 26 // グループがプロジェクトから脱退
+)
+
+var activityTypeNames = map[ActivityType]string{
+	ActivityTypeIssueCreated:         "IssueCreated",
+	ActivityTypeIssueUpdated:         "IssueUpdated",
+	ActivityTypeIssueCommented:       "IssueCommented",
+	ActivityTypeIssueDeleted:         "IssueDeleted",
+	ActivityTypeWikiCreated:          "WikiCreated",
+	ActivityTypeWikiUpdated:          "WikiUpdated",
+	ActivityTypeWikiDeleted:          "WikiDeleted",
+	ActivityTypeFileAdded:            "FileAdded",
+	ActivityTypeFileUpdated:          "FileUpdated",
+	ActivityTypeFileDeleted:          "FileDeleted",
+	ActivityTypeSVNCommitted:         "SVNCommitted",
+	ActivityTypeGitPushed:            "GitPushed",
+	ActivityTypeGitRepositoryCreated: "GitRepositoryCreated",
+	ActivityTypeIssueMultiUpdated:    "IssueMultiUpdated",
+	ActivityTypeProjectUserAdded:     "ProjectUserAdded",
+	ActivityTypeProjectUserRemoved:   "ProjectUserRemoved",
+	ActivityTypeNotificationAdded:    "NotificationAdded",
+	ActivityTypePullRequestAdded:     "PullRequestAdded",
+	ActivityTypePullRequestUpdated:   "PullRequestUpdated",
+	ActivityTypePullRequestCommented: "PullRequestCommented",
+	ActivityTypePullRequestDeleted:   "PullRequestDeleted",
+	ActivityTypeMilestoneCreated:     "MilestoneCreated",
+	ActivityTypeMilestoneUpdated:     "MilestoneUpdated",
+	ActivityTypeMilestoneDeleted:     "MilestoneDeleted",
+	ActivityTypeProjectGroupAdded:    "ProjectGroupAdded",
+	ActivityTypeProjectGroupRemoved:  "ProjectGroupRemoved",
+}
+
+func (t ActivityType) String() string {
+	if name, ok := activityTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ActivityType(%d)", int(t))
+}
+
+// Activity is Backlog activity (recent update) in the space, the project or of the user
+type Activity struct {
+	ID          int          `json:"id"`
+	Project     *Project     `json:"project"`
+	Type        ActivityType `json:"type"`
+	CreatedUser User         `json:"createdUser"`
+	Created     time.Time    `json:"created"`
+
+	// Content is decoded into the struct for Type, such as *IssueActivityContent
+	// or *GitPushActivityContent. The content of the types without a struct is
+	// decoded into GenericActivityContent.
+	Content    interface{}     `json:"-"`
+	RawContent json.RawMessage `json:"content"`
+}
+
+// ActivityChange is a changed field in an activity
+type ActivityChange struct {
+	Field    string `json:"field"`
+	NewValue string `json:"new_value"`
+	OldValue string `json:"old_value"`
+	Type     string `json:"type"`
+}
+
+// ActivityComment is a comment in an activity
+type ActivityComment struct {
+	ID      int    `json:"id"`
+	Content string `json:"content"`
+}
+
+// ActivityAttachment is an attached file in an activity
+type ActivityAttachment struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// ActivitySharedFile is a shared file in an activity
+type ActivitySharedFile struct {
+	ID   int    `json:"id"`
+	Dir  string `json:"dir"`
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// ActivityRepository is a Git repository in an activity
+type ActivityRepository struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ActivityRevision is a revision in an activity
+type ActivityRevision struct {
+	Rev     string `json:"rev"`
+	Comment string `json:"comment"`
+}
+
+// ActivityIssueLink is a reference to an issue in an activity
+type ActivityIssueLink struct {
+	ID      int    `json:"id"`
+	KeyID   int    `json:"key_id"`
+	Summary string `json:"summary"`
+	Title   string `json:"title"`
+}
+
+// ActivityGroup is a group in an activity
+type ActivityGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// IssueActivityContent is the content of the issue activities and ActivityTypeNotificationAdded.
+type IssueActivityContent struct {
+	ID          int                  `json:"id"`
+	KeyID       int                  `json:"key_id"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Comment     *ActivityComment     `json:"comment"`
+	Changes     []ActivityChange     `json:"changes"`
+	Attachments []ActivityAttachment `json:"attachments"`
+	SharedFiles []ActivitySharedFile `json:"shared_files"`
+}
+
+// IssueMultiUpdateActivityContent is the content of ActivityTypeIssueMultiUpdated.
+type IssueMultiUpdateActivityContent struct {
+	TxID    int                 `json:"tx_id"`
+	Comment *ActivityComment    `json:"comment"`
+	Link    []ActivityIssueLink `json:"link"`
+	Changes []ActivityChange    `json:"changes"`
+}
+
+// WikiActivityContent is the content of the wiki activities.
+type WikiActivityContent struct {
+	ID          int                  `json:"id"`
+	Name        string               `json:"name"`
+	Content     string               `json:"content"`
+	Diff        string               `json:"diff"`
+	Version     int                  `json:"version"`
+	Attachments []ActivityAttachment `json:"attachments"`
+	SharedFiles []ActivitySharedFile `json:"shared_files"`
+}
+
+// FileActivityContent is the content of the shared file activities.
+type FileActivityContent struct {
+	ID   int    `json:"id"`
+	Dir  string `json:"dir"`
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// SVNActivityContent is the content of ActivityTypeSVNCommitted.
+type SVNActivityContent struct {
+	Rev     int    `json:"rev"`
+	Comment string `json:"comment"`
+}
+
+// GitPushActivityContent is the content of ActivityTypeGitPushed and ActivityTypeGitRepositoryCreated.
+type GitPushActivityContent struct {
+	Repository    ActivityRepository `json:"repository"`
+	ChangeType    string             `json:"change_type"`
+	RevisionType  string             `json:"revision_type"`
+	Ref           string             `json:"ref"`
+	RevisionCount int                `json:"revision_count"`
+	Revisions     []ActivityRevision `json:"revisions"`
+}
+
+// ProjectUserActivityContent is the content of ActivityTypeProjectUserAdded and ActivityTypeProjectUserRemoved.
+type ProjectUserActivityContent struct {
+	Users   []User `json:"users"`
+	Comment string `json:"comment"`
+}
+
+// PullRequestActivityContent is the content of the pull request activities.
+type PullRequestActivityContent struct {
+	ID          int                `json:"id"`
+	Number      int                `json:"number"`
+	Summary     string             `json:"summary"`
+	Description string             `json:"description"`
+	Comment     *ActivityComment   `json:"comment"`
+	Changes     []ActivityChange   `json:"changes"`
+	Repository  ActivityRepository `json:"repository"`
+	Issue       *ActivityIssueLink `json:"issue"`
+}
+
+// MilestoneActivityContent is the content of the milestone activities.
+type MilestoneActivityContent struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	StartDate     string           `json:"start_date"`
+	ReferenceDate string           `json:"reference_date"`
+	Changes       []ActivityChange `json:"changes"`
+}
+
+// ProjectGroupActivityContent is the content of ActivityTypeProjectGroupAdded and ActivityTypeProjectGroupRemoved.
+type ProjectGroupActivityContent struct {
+	Groups []ActivityGroup `json:"groups"`
+}
+
+// GenericActivityContent is the content of the activity types which have no
+// specific struct, such as the types added to Backlog after this package.
+type GenericActivityContent map[string]interface{}
+
+// UnmarshalJSON decodes the activity and its content for the activity type.
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	type activity Activity
+	var v activity
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*a = Activity(v)
+
+	if len(a.RawContent) == 0 || string(a.RawContent) == "null" {
+		return nil
+	}
+	content := newActivityContent(a.Type)
+	if content == nil {
+		generic := GenericActivityContent{}
+		if err := json.Unmarshal(a.RawContent, &generic); err != nil {
+			return fmt.Errorf("backlog: decoding content of %v activity %d: %v", a.Type, a.ID, err)
+		}
+		a.Content = generic
+		return nil
+	}
+	if err := json.Unmarshal(a.RawContent, content); err != nil {
+		return fmt.Errorf("backlog: decoding content of %v activity %d: %v", a.Type, a.ID, err)
+	}
+	a.Content = content
+	return nil
+}
+
+func newActivityContent(t ActivityType) interface{} {
+	switch t {
+	case ActivityTypeIssueCreated, ActivityTypeIssueUpdated, ActivityTypeIssueCommented,
+		ActivityTypeIssueDeleted, ActivityTypeNotificationAdded:
+		return new(IssueActivityContent)
+	case ActivityTypeIssueMultiUpdated:
+		return new(IssueMultiUpdateActivityContent)
+	case ActivityTypeWikiCreated, ActivityTypeWikiUpdated, ActivityTypeWikiDeleted:
+		return new(WikiActivityContent)
+	case ActivityTypeFileAdded, ActivityTypeFileUpdated, ActivityTypeFileDeleted:
+		return new(FileActivityContent)
+	case ActivityTypeSVNCommitted:
+		return new(SVNActivityContent)
+	case ActivityTypeGitPushed, ActivityTypeGitRepositoryCreated:
+		return new(GitPushActivityContent)
+	case ActivityTypeProjectUserAdded, ActivityTypeProjectUserRemoved:
+		return new(ProjectUserActivityContent)
+	case ActivityTypePullRequestAdded, ActivityTypePullRequestUpdated,
+		ActivityTypePullRequestCommented, ActivityTypePullRequestDeleted:
+		return new(PullRequestActivityContent)
+	case ActivityTypeMilestoneCreated, ActivityTypeMilestoneUpdated, ActivityTypeMilestoneDeleted:
+		return new(MilestoneActivityContent)
+	case ActivityTypeProjectGroupAdded, ActivityTypeProjectGroupRemoved:
+		return new(ProjectGroupActivityContent)
+	}
+	return nil
+}
+
+// ActivityListRequest represents a request to list activities.
+// Use MaxID with the smallest ID of the previous page to fetch older activities.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-recent-updates/
+type ActivityListRequest struct {
+	ActivityTypeIDs []int   `url:"activityTypeId[],omitempty"` // 種別のID (ActivityType)
+	MinID           *int    `url:"minId,omitempty"`            // 最小ID
+	MaxID           *int    `url:"maxId,omitempty"`            // 最大ID
+	Count           *int    `url:"count,omitempty"`            // 取得上限 (1-100) 指定が無い場合は 20
+	Order           *string `url:"order,omitempty"`            // `asc` または `desc` 指定が無い場合は `desc`
+}
+
+// ListActivities lists recent updates in the space.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-recent-updates/
+func (s *SpaceService) ListActivities(request ActivityListRequest) ([]*Activity, *Response, error) {
+	return listActivities(s.client, "space/activities", request)
+}
+
+// ListActivities lists recent updates in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-project-recent-updates/
+func (s *ProjectsService) ListActivities(projectKey string, request ActivityListRequest) ([]*Activity, *Response, error) {
+	return listActivities(s.client, "projects/"+projectKey+"/activities", request)
+}
+
+// ListActivities lists recent updates of the user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-user-recent-updates/
+func (s *UsersService) ListActivities(userID int, request ActivityListRequest) ([]*Activity, *Response, error) {
+	return listActivities(s.client, fmt.Sprintf("users/%d/activities", userID), request)
+}
+
+func listActivities(c *Client, path string, request ActivityListRequest) ([]*Activity, *Response, error) {
+	u, _ := addOptions(path, request)
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	activities := []*Activity{}
+	resp, err := c.Do(req, &activities)
+	if err != nil {
+		return nil, resp, err
+	}
+	return activities, resp, nil
+}
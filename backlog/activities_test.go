@@ -0,0 +1,118 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSpaceService_ListActivities(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/space/activities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query()["activityTypeId[]"], []string{"2", "12"}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("activityTypeId[]: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[
+			{"id":3,"type":2,"project":{"id":1,"projectKey":"TEST"},"createdUser":{"id":1,"userId":"admin"},
+			 "content":{"id":10,"key_id":5,"summary":"bug","changes":[{"field":"status","new_value":"処理中","old_value":"未対応","type":"standard"}]}},
+			{"id":2,"type":12,"content":{"repository":{"id":4,"name":"app"},"ref":"refs/heads/master","revision_count":1,"revisions":[{"rev":"abc","comment":"fix"}]}},
+			{"id":1,"type":99,"content":{"foo":"bar"}}
+		]`)
+	})
+
+	activities, _, err := client.Space.ListActivities(ActivityListRequest{
+		ActivityTypeIDs: []int{int(ActivityTypeIssueUpdated), int(ActivityTypeGitPushed)},
+	})
+	if err != nil {
+		t.Fatalf("Space.ListActivities returned error: %v", err)
+	}
+	if len(activities) != 3 {
+		t.Fatalf("Space.ListActivities returned %d activities, want 3", len(activities))
+	}
+
+	issue, ok := activities[0].Content.(*IssueActivityContent)
+	if !ok {
+		t.Fatalf("Content of %v is %T, want *IssueActivityContent", activities[0].Type, activities[0].Content)
+	}
+	if issue.KeyID != 5 || len(issue.Changes) != 1 || issue.Changes[0].NewValue != "処理中" {
+		t.Errorf("IssueActivityContent is %+v", issue)
+	}
+
+	push, ok := activities[1].Content.(*GitPushActivityContent)
+	if !ok {
+		t.Fatalf("Content of %v is %T, want *GitPushActivityContent", activities[1].Type, activities[1].Content)
+	}
+	if push.Repository.Name != "app" || len(push.Revisions) != 1 || push.Revisions[0].Rev != "abc" {
+		t.Errorf("GitPushActivityContent is %+v", push)
+	}
+
+	generic, ok := activities[2].Content.(GenericActivityContent)
+	if !ok || generic["foo"] != "bar" {
+		t.Errorf("unknown activity has Content %#v, want GenericActivityContent", activities[2].Content)
+	}
+	if got, want := activities[2].Type.String(), "ActivityType(99)"; got != want {
+		t.Errorf("ActivityType.String() returned %v, want %v", got, want)
+	}
+}
+
+func TestActivity_UnmarshalJSON_contentTypes(t *testing.T) {
+	tests := []struct {
+		typ  ActivityType
+		want string
+	}{
+		{ActivityTypeIssueCommented, "*backlog.IssueActivityContent"},
+		{ActivityTypeWikiUpdated, "*backlog.WikiActivityContent"},
+		{ActivityTypeFileAdded, "*backlog.FileActivityContent"},
+		{ActivityTypeSVNCommitted, "*backlog.SVNActivityContent"},
+		{ActivityTypeGitRepositoryCreated, "*backlog.GitPushActivityContent"},
+		{ActivityTypeIssueMultiUpdated, "*backlog.IssueMultiUpdateActivityContent"},
+		{ActivityTypeProjectUserRemoved, "*backlog.ProjectUserActivityContent"},
+		{ActivityTypeNotificationAdded, "*backlog.IssueActivityContent"},
+		{ActivityTypePullRequestAdded, "*backlog.PullRequestActivityContent"},
+		{ActivityTypePullRequestUpdated, "*backlog.PullRequestActivityContent"},
+		{ActivityTypePullRequestCommented, "*backlog.PullRequestActivityContent"},
+		{ActivityTypePullRequestDeleted, "*backlog.PullRequestActivityContent"},
+		{ActivityTypeMilestoneUpdated, "*backlog.MilestoneActivityContent"},
+		{ActivityTypeProjectGroupRemoved, "*backlog.ProjectGroupActivityContent"},
+		{ActivityTypeProjectGroupRemoved + 1, "backlog.GenericActivityContent"},
+		{ActivityTypeProjectGroupRemoved + 20, "backlog.GenericActivityContent"},
+	}
+	for _, tt := range tests {
+		var a Activity
+		data := fmt.Sprintf(`{"id":1,"type":%d,"content":{"id":2,"number":3,"repository":{"name":"app"}}}`, tt.typ)
+		if err := a.UnmarshalJSON([]byte(data)); err != nil {
+			t.Errorf("UnmarshalJSON of %v returned error: %v", tt.typ, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", a.Content); got != tt.want {
+			t.Errorf("Content of %v is %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+
+	var a Activity
+	data := `{"id":1,"type":18,"content":{"id":2,"number":3,"summary":"fix","repository":{"id":4,"name":"app"},"issue":{"id":5,"key_id":6}}}`
+	if err := a.UnmarshalJSON([]byte(data)); err != nil {
+		t.Fatalf("UnmarshalJSON returned error: %v", err)
+	}
+	pr := a.Content.(*PullRequestActivityContent)
+	if pr.Number != 3 || pr.Repository.Name != "app" || pr.Issue == nil || pr.Issue.KeyID != 6 {
+		t.Errorf("PullRequestActivityContent is %+v", pr)
+	}
+}
+
+func TestActivityType_String(t *testing.T) {
+	for typ := ActivityTypeIssueCreated; typ <= ActivityTypeProjectGroupRemoved; typ++ {
+		if _, ok := activityTypeNames[typ]; !ok {
+			t.Errorf("ActivityType %d has no name", int(typ))
+		}
+		if newActivityContent(typ) == nil {
+			t.Errorf("%v has no content struct", typ)
+		}
+	}
+	if got, want := ActivityTypePullRequestCommented.String(), "PullRequestCommented"; got != want {
+		t.Errorf("String() returned %v, want %v", got, want)
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -21,10 +23,26 @@ type Client struct {
 	common  service
 	apiKey  string
 
+	rateLimitCheck *rateLimitChecker
+	middlewares    []Middleware
+	cache          *clientCache
+
+	// Logger logs every request if set. The API key and tokens are redacted.
+	Logger *slog.Logger
+
+	// LogBodies adds the request and response bodies to the logs.
+	// Bodies written to io.Writer, such as downloaded files, are not logged.
+	LogBodies bool
+
 	// Services
-	Space    *SpaceService
-	Projects *ProjectsService
-	Issues   *IssuesService
+	Space         *SpaceService
+	Projects      *ProjectsService
+	Issues        *IssuesService
+	Notifications *NotificationsService
+	Watchings     *WatchingsService
+	Stars         *StarsService
+	Users         *UsersService
+	Documents     *DocumentsService
 }
 
 type service struct {
@@ -43,6 +61,11 @@ func NewClient(httpClient *http.Client, space string, apiKey string) *Client {
 	c.Space = (*SpaceService)(&c.common)
 	c.Projects = (*ProjectsService)(&c.common)
 	c.Issues = (*IssuesService)(&c.common)
+	c.Notifications = (*NotificationsService)(&c.common)
+	c.Watchings = (*WatchingsService)(&c.common)
+	c.Stars = (*StarsService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.Documents = (*DocumentsService)(&c.common)
 	return c
 }
 
@@ -61,9 +84,6 @@ func (c *Client) NewRequest(method, urlStr string, params *url.Values) (*http.Re
 	q.Set("apiKey", c.apiKey)
 	u.RawQuery = q.Encode()
 
-	// Debug
-	// fmt.Println("u.String():", u.String())
-
 	var buf io.ReadWriter
 	if params != nil {
 		buf = bytes.NewBufferString(params.Encode())
@@ -86,50 +106,131 @@ func (c *Client) NewRequest(method, urlStr string, params *url.Values) (*http.Re
 // Response is a Backlog API response.
 type Response struct {
 	*http.Response
+
+	// RateLimit is the rate limit of the bucket of the request, or nil if the
+	// response does not have the X-RateLimit-* headers.
+	RateLimit *RateLimit
+
+	// Duration is the time until the response headers were received.
+	Duration time.Duration
+
+	// RequestID is the X-Request-Id header if present.
+	RequestID string
+
+	// Offset and Count are the effective offset and count of a list request.
+	Offset int
+	Count  int
+
+	// NextPage is the cursor of the next page of a list, or nil if the list
+	// has no more items or the request is not a list.
+	NextPage *NextPage
+}
+
+// TypedResponse is a Response with the value returned by a service method.
+type TypedResponse[T any] struct {
+	*Response
+
+	Value T // e.g. []*Issue of IssuesService.Search
+}
+
+// Typed combines the results of a service method into a TypedResponse.
+// The TypedResponse is returned with the error if the response was received.
+//
+//	r, err := backlog.Typed(client.Issues.Search(request))
+//	issues, next := r.Value, r.NextPage
+func Typed[T any](value T, resp *Response, err error) (*TypedResponse[T], error) {
+	if resp == nil && err != nil {
+		return nil, err
+	}
+	return &TypedResponse[T]{Response: resp, Value: value}, err
 }
 
 // newResponse creates a new Response for the provided http.Response.
 // r must not be nil.
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
+	if rate, ok := rateLimitFromHeader(r.Header); ok {
+		response.RateLimit = &rate
+	}
+	response.RequestID = r.Header.Get("X-Request-Id")
 	return response
 }
 
+// countResponse is the response body of the count APIs.
+type countResponse struct {
+	Count int `json:"count"`
+}
+
 // Do sends an API request and returns the API response.
+// The response body is JSON decoded into v, or written to v as it is if v
+// implements io.Writer.
+// The request passes through the middlewares added by Use.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	var d Doer = DoerFunc(c.do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		d = c.middlewares[i](d)
+	}
+	return d.Do(req, v)
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	start := time.Now()
+	resp, err := c.send(req)
 	if err != nil {
 		// If the error type is *url.Error, sanitize its URL before returning.
 		if e, ok := err.(*url.Error); ok {
 			if url, err := url.Parse(e.URL); err == nil {
 				e.URL = sanitizeURL(url).String()
-				return nil, e
 			}
 		}
+		c.logResponse(req, nil, nil, time.Since(start), err)
 		return nil, err
 	}
 	defer resp.Body.Close()
+	duration := time.Since(start)
+
+	var body []byte
+	if _, ok := v.(io.Writer); !ok && c.Logger != nil && c.LogBodies {
+		body, _ = ioutil.ReadAll(resp.Body)
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
 
 	response := newResponse(resp)
+	response.Duration = duration
 
 	err = CheckResponse(resp)
 	if err != nil {
+		c.logResponse(req, resp, body, duration, err)
 		return response, err
 	}
 
 	if v != nil {
-		decErr := json.NewDecoder(resp.Body).Decode(v)
-		if decErr == io.EOF {
-			decErr = nil // ignore EOF errors caused by empty response body
-		}
-		if decErr != nil {
-			err = decErr
+		if w, ok := v.(io.Writer); ok {
+			_, err = io.Copy(w, resp.Body)
+		} else {
+			decErr := json.NewDecoder(resp.Body).Decode(v)
+			if decErr == io.EOF {
+				decErr = nil // ignore EOF errors caused by empty response body
+			}
+			if decErr != nil {
+				err = decErr
+			}
 		}
 	}
 
+	if err == nil {
+		response.setPage(req, c.relativePath(req), v)
+	}
+
+	c.logResponse(req, resp, body, duration, err)
 	return response, err
 }
 
+// relativePath returns the path of req relative to BaseURL, e.g. "issues/WEB-1".
+func (c *Client) relativePath(req *http.Request) string {
+	return strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+}
+
 // CheckResponse checks the API response for errors, and returns them if present.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
@@ -170,6 +271,15 @@ type Error struct {
 	MoreInfo string `json:"moreInfo"`
 }
 
+// SanitizeURL returns a copy of uri whose apiKey parameter is redacted.
+func SanitizeURL(uri *url.URL) *url.URL {
+	if uri == nil {
+		return nil
+	}
+	u := *uri
+	return sanitizeURL(&u)
+}
+
 // sanitizeURL redacts the apiKey parameter from the URL which may be exposed to the user.
 func sanitizeURL(uri *url.URL) *url.URL {
 	if uri == nil {
@@ -183,6 +293,21 @@ func sanitizeURL(uri *url.URL) *url.URL {
 	return uri
 }
 
+// sensitiveHeaders are the headers which carry credentials.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// IsSensitiveHeader reports whether the header carries credentials, such as
+// Authorization and Set-Cookie, which must not be stored.
+func IsSensitiveHeader(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	for _, k := range sensitiveHeaders {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // addOptions adds the parameters in opt as URL query parameters to s. opt
 // must be a struct whose fields may contain "url" tags.
 // https://github.com/google/go-github/blob/99760a16213d6fdde13f4e477438f876b6c9c6eb/github/github.go#L212-L232
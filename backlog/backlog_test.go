@@ -1,6 +1,8 @@
 package backlog
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
@@ -8,6 +10,25 @@ import (
 	pointers "github.com/f2prateek/go-pointers"
 )
 
+// setup sets up a test HTTP server along with a Client that is configured to
+// talk to that test server. Tests should register handlers on mux which provide
+// mock responses for the API method being tested.
+func setup() (client *Client, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client = NewClient(nil, "example", "secret")
+	client.BaseURL, _ = url.Parse(server.URL + "/api/v2/")
+
+	return client, mux, server.Close
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	if got := r.Method; got != want {
+		t.Errorf("Request method: %v, want %v", got, want)
+	}
+}
+
 // https://github.com/google/go-github/blob/99760a16213d6fdde13f4e477438f876b6c9c6eb/github/github_test.go#L761-L778
 func TestSanitizeURL(t *testing.T) {
 	tests := []struct {
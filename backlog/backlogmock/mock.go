@@ -0,0 +1,658 @@
+// Code generated by mockgen from interfaces.go. DO NOT EDIT.
+
+// Package backlogmock provides mocks of the backlog service interfaces.
+// Set the Func field of each method called by the code under test;
+// calling a method whose Func is nil panics.
+package backlogmock
+
+import (
+	"io"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+var (
+	_ backlog.IssuesAPI        = (*IssuesAPI)(nil)
+	_ backlog.ProjectsAPI      = (*ProjectsAPI)(nil)
+	_ backlog.SpaceAPI         = (*SpaceAPI)(nil)
+	_ backlog.UsersAPI         = (*UsersAPI)(nil)
+	_ backlog.NotificationsAPI = (*NotificationsAPI)(nil)
+	_ backlog.WatchingsAPI     = (*WatchingsAPI)(nil)
+	_ backlog.StarsAPI         = (*StarsAPI)(nil)
+	_ backlog.DocumentsAPI     = (*DocumentsAPI)(nil)
+)
+
+// IssuesAPI is a mock of backlog.IssuesAPI.
+type IssuesAPI struct {
+	GetFunc              func(issueKey string) (*backlog.Issue, *backlog.Response, error)
+	CreateFunc           func(request backlog.IssueRequest) (*backlog.Issue, *backlog.Response, error)
+	EditFunc             func(issueKey string, request backlog.IssueRequest) (*backlog.Issue, *backlog.Response, error)
+	DeleteFunc           func(issueKey string) (*backlog.Response, error)
+	SearchFunc           func(request backlog.IssueSearchRequest) ([]*backlog.Issue, *backlog.Response, error)
+	ListCommentsFunc     func(issueKey string, order string) ([]*backlog.IssueComment, *backlog.Response, error)
+	CreateCommentFunc    func(issueKey string, comment string) (*backlog.IssueComment, *backlog.Response, error)
+	ListParticipantsFunc func(issueKey string) ([]*backlog.User, *backlog.Response, error)
+	SearchAllFunc        func(request backlog.IssueSearchRequest) ([]*backlog.Issue, *backlog.Response, error)
+	ListChildrenFunc     func(parentKey string) ([]*backlog.Issue, *backlog.Response, error)
+	GetParentFunc        func(issueKey string) (*backlog.Issue, *backlog.Response, error)
+	TreeFunc             func(projectKey string) ([]*backlog.IssueNode, *backlog.Response, error)
+	QueryFunc            func(query string, r *backlog.Resolver) ([]*backlog.Issue, *backlog.Response, error)
+	WatchFunc            func(issueKey string, note string) (*backlog.Watching, *backlog.Response, error)
+	UnwatchFunc          func(userID int, issueKey string) (*backlog.Watching, *backlog.Response, error)
+	GetManyFunc          func(keys []string, opt *backlog.BulkOptions) []backlog.IssueResult
+	BulkEditFunc         func(search backlog.IssueSearchRequest, change backlog.IssueRequest, opt *backlog.BulkEditOptions) (*backlog.BulkEditReport, error)
+}
+
+// Get calls GetFunc.
+func (m *IssuesAPI) Get(issueKey string) (*backlog.Issue, *backlog.Response, error) {
+	if m.GetFunc == nil {
+		panic("backlogmock: IssuesAPI.GetFunc is not set")
+	}
+	return m.GetFunc(issueKey)
+}
+
+// Create calls CreateFunc.
+func (m *IssuesAPI) Create(request backlog.IssueRequest) (*backlog.Issue, *backlog.Response, error) {
+	if m.CreateFunc == nil {
+		panic("backlogmock: IssuesAPI.CreateFunc is not set")
+	}
+	return m.CreateFunc(request)
+}
+
+// Edit calls EditFunc.
+func (m *IssuesAPI) Edit(issueKey string, request backlog.IssueRequest) (*backlog.Issue, *backlog.Response, error) {
+	if m.EditFunc == nil {
+		panic("backlogmock: IssuesAPI.EditFunc is not set")
+	}
+	return m.EditFunc(issueKey, request)
+}
+
+// Delete calls DeleteFunc.
+func (m *IssuesAPI) Delete(issueKey string) (*backlog.Response, error) {
+	if m.DeleteFunc == nil {
+		panic("backlogmock: IssuesAPI.DeleteFunc is not set")
+	}
+	return m.DeleteFunc(issueKey)
+}
+
+// Search calls SearchFunc.
+func (m *IssuesAPI) Search(request backlog.IssueSearchRequest) ([]*backlog.Issue, *backlog.Response, error) {
+	if m.SearchFunc == nil {
+		panic("backlogmock: IssuesAPI.SearchFunc is not set")
+	}
+	return m.SearchFunc(request)
+}
+
+// ListComments calls ListCommentsFunc.
+func (m *IssuesAPI) ListComments(issueKey string, order string) ([]*backlog.IssueComment, *backlog.Response, error) {
+	if m.ListCommentsFunc == nil {
+		panic("backlogmock: IssuesAPI.ListCommentsFunc is not set")
+	}
+	return m.ListCommentsFunc(issueKey, order)
+}
+
+// CreateComment calls CreateCommentFunc.
+func (m *IssuesAPI) CreateComment(issueKey string, comment string) (*backlog.IssueComment, *backlog.Response, error) {
+	if m.CreateCommentFunc == nil {
+		panic("backlogmock: IssuesAPI.CreateCommentFunc is not set")
+	}
+	return m.CreateCommentFunc(issueKey, comment)
+}
+
+// ListParticipants calls ListParticipantsFunc.
+func (m *IssuesAPI) ListParticipants(issueKey string) ([]*backlog.User, *backlog.Response, error) {
+	if m.ListParticipantsFunc == nil {
+		panic("backlogmock: IssuesAPI.ListParticipantsFunc is not set")
+	}
+	return m.ListParticipantsFunc(issueKey)
+}
+
+// SearchAll calls SearchAllFunc.
+func (m *IssuesAPI) SearchAll(request backlog.IssueSearchRequest) ([]*backlog.Issue, *backlog.Response, error) {
+	if m.SearchAllFunc == nil {
+		panic("backlogmock: IssuesAPI.SearchAllFunc is not set")
+	}
+	return m.SearchAllFunc(request)
+}
+
+// ListChildren calls ListChildrenFunc.
+func (m *IssuesAPI) ListChildren(parentKey string) ([]*backlog.Issue, *backlog.Response, error) {
+	if m.ListChildrenFunc == nil {
+		panic("backlogmock: IssuesAPI.ListChildrenFunc is not set")
+	}
+	return m.ListChildrenFunc(parentKey)
+}
+
+// GetParent calls GetParentFunc.
+func (m *IssuesAPI) GetParent(issueKey string) (*backlog.Issue, *backlog.Response, error) {
+	if m.GetParentFunc == nil {
+		panic("backlogmock: IssuesAPI.GetParentFunc is not set")
+	}
+	return m.GetParentFunc(issueKey)
+}
+
+// Tree calls TreeFunc.
+func (m *IssuesAPI) Tree(projectKey string) ([]*backlog.IssueNode, *backlog.Response, error) {
+	if m.TreeFunc == nil {
+		panic("backlogmock: IssuesAPI.TreeFunc is not set")
+	}
+	return m.TreeFunc(projectKey)
+}
+
+// Query calls QueryFunc.
+func (m *IssuesAPI) Query(query string, r *backlog.Resolver) ([]*backlog.Issue, *backlog.Response, error) {
+	if m.QueryFunc == nil {
+		panic("backlogmock: IssuesAPI.QueryFunc is not set")
+	}
+	return m.QueryFunc(query, r)
+}
+
+// Watch calls WatchFunc.
+func (m *IssuesAPI) Watch(issueKey string, note string) (*backlog.Watching, *backlog.Response, error) {
+	if m.WatchFunc == nil {
+		panic("backlogmock: IssuesAPI.WatchFunc is not set")
+	}
+	return m.WatchFunc(issueKey, note)
+}
+
+// Unwatch calls UnwatchFunc.
+func (m *IssuesAPI) Unwatch(userID int, issueKey string) (*backlog.Watching, *backlog.Response, error) {
+	if m.UnwatchFunc == nil {
+		panic("backlogmock: IssuesAPI.UnwatchFunc is not set")
+	}
+	return m.UnwatchFunc(userID, issueKey)
+}
+
+// GetMany calls GetManyFunc.
+func (m *IssuesAPI) GetMany(keys []string, opt *backlog.BulkOptions) []backlog.IssueResult {
+	if m.GetManyFunc == nil {
+		panic("backlogmock: IssuesAPI.GetManyFunc is not set")
+	}
+	return m.GetManyFunc(keys, opt)
+}
+
+// BulkEdit calls BulkEditFunc.
+func (m *IssuesAPI) BulkEdit(search backlog.IssueSearchRequest, change backlog.IssueRequest, opt *backlog.BulkEditOptions) (*backlog.BulkEditReport, error) {
+	if m.BulkEditFunc == nil {
+		panic("backlogmock: IssuesAPI.BulkEditFunc is not set")
+	}
+	return m.BulkEditFunc(search, change, opt)
+}
+
+// ProjectsAPI is a mock of backlog.ProjectsAPI.
+type ProjectsAPI struct {
+	ListAllFunc         func() ([]*backlog.Project, *backlog.Response, error)
+	GetFunc             func(projectKey string) (*backlog.Project, *backlog.Response, error)
+	ListIssueTypesFunc  func(projectKey string) ([]*backlog.IssueType, *backlog.Response, error)
+	ListCategoriesFunc  func(projectKey string) ([]*backlog.Category, *backlog.Response, error)
+	ListVersionsFunc    func(projectKey string) ([]*backlog.Version, *backlog.Response, error)
+	ListUsersFunc       func(projectKey string) ([]*backlog.User, *backlog.Response, error)
+	CreateCategoryFunc  func(projectKey string, categoryName string) (*backlog.Category, *backlog.Response, error)
+	DeleteCategoryFunc  func(projectKey string, categoryID string) (*backlog.Response, error)
+	CreateIssueTypeFunc func(projectKey string, name string, color string) (*backlog.IssueType, *backlog.Response, error)
+	DeleteIssueTypeFunc func(projectKey string, issueTypeID string, substituteIssueTypeID string) (*backlog.Response, error)
+	ListWebhooksFunc    func(projectKey string) ([]*backlog.Webhook, *backlog.Response, error)
+	GetWebhookFunc      func(projectKey string, webhookID int) (*backlog.Webhook, *backlog.Response, error)
+	CreateWebhookFunc   func(projectKey string, request backlog.WebhookRequest) (*backlog.Webhook, *backlog.Response, error)
+	UpdateWebhookFunc   func(projectKey string, webhookID int, request backlog.WebhookRequest) (*backlog.Webhook, *backlog.Response, error)
+	DeleteWebhookFunc   func(projectKey string, webhookID int) (*backlog.Webhook, *backlog.Response, error)
+	GetMetadataFunc     func(projectKeys []string, opt *backlog.BulkOptions) []backlog.ProjectMetadata
+	ListActivitiesFunc  func(projectKey string, request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error)
+}
+
+// ListAll calls ListAllFunc.
+func (m *ProjectsAPI) ListAll() ([]*backlog.Project, *backlog.Response, error) {
+	if m.ListAllFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListAllFunc is not set")
+	}
+	return m.ListAllFunc()
+}
+
+// Get calls GetFunc.
+func (m *ProjectsAPI) Get(projectKey string) (*backlog.Project, *backlog.Response, error) {
+	if m.GetFunc == nil {
+		panic("backlogmock: ProjectsAPI.GetFunc is not set")
+	}
+	return m.GetFunc(projectKey)
+}
+
+// ListIssueTypes calls ListIssueTypesFunc.
+func (m *ProjectsAPI) ListIssueTypes(projectKey string) ([]*backlog.IssueType, *backlog.Response, error) {
+	if m.ListIssueTypesFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListIssueTypesFunc is not set")
+	}
+	return m.ListIssueTypesFunc(projectKey)
+}
+
+// ListCategories calls ListCategoriesFunc.
+func (m *ProjectsAPI) ListCategories(projectKey string) ([]*backlog.Category, *backlog.Response, error) {
+	if m.ListCategoriesFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListCategoriesFunc is not set")
+	}
+	return m.ListCategoriesFunc(projectKey)
+}
+
+// ListVersions calls ListVersionsFunc.
+func (m *ProjectsAPI) ListVersions(projectKey string) ([]*backlog.Version, *backlog.Response, error) {
+	if m.ListVersionsFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListVersionsFunc is not set")
+	}
+	return m.ListVersionsFunc(projectKey)
+}
+
+// ListUsers calls ListUsersFunc.
+func (m *ProjectsAPI) ListUsers(projectKey string) ([]*backlog.User, *backlog.Response, error) {
+	if m.ListUsersFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListUsersFunc is not set")
+	}
+	return m.ListUsersFunc(projectKey)
+}
+
+// CreateCategory calls CreateCategoryFunc.
+func (m *ProjectsAPI) CreateCategory(projectKey string, categoryName string) (*backlog.Category, *backlog.Response, error) {
+	if m.CreateCategoryFunc == nil {
+		panic("backlogmock: ProjectsAPI.CreateCategoryFunc is not set")
+	}
+	return m.CreateCategoryFunc(projectKey, categoryName)
+}
+
+// DeleteCategory calls DeleteCategoryFunc.
+func (m *ProjectsAPI) DeleteCategory(projectKey string, categoryID string) (*backlog.Response, error) {
+	if m.DeleteCategoryFunc == nil {
+		panic("backlogmock: ProjectsAPI.DeleteCategoryFunc is not set")
+	}
+	return m.DeleteCategoryFunc(projectKey, categoryID)
+}
+
+// CreateIssueType calls CreateIssueTypeFunc.
+func (m *ProjectsAPI) CreateIssueType(projectKey string, name string, color string) (*backlog.IssueType, *backlog.Response, error) {
+	if m.CreateIssueTypeFunc == nil {
+		panic("backlogmock: ProjectsAPI.CreateIssueTypeFunc is not set")
+	}
+	return m.CreateIssueTypeFunc(projectKey, name, color)
+}
+
+// DeleteIssueType calls DeleteIssueTypeFunc.
+func (m *ProjectsAPI) DeleteIssueType(projectKey string, issueTypeID string, substituteIssueTypeID string) (*backlog.Response, error) {
+	if m.DeleteIssueTypeFunc == nil {
+		panic("backlogmock: ProjectsAPI.DeleteIssueTypeFunc is not set")
+	}
+	return m.DeleteIssueTypeFunc(projectKey, issueTypeID, substituteIssueTypeID)
+}
+
+// ListWebhooks calls ListWebhooksFunc.
+func (m *ProjectsAPI) ListWebhooks(projectKey string) ([]*backlog.Webhook, *backlog.Response, error) {
+	if m.ListWebhooksFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListWebhooksFunc is not set")
+	}
+	return m.ListWebhooksFunc(projectKey)
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (m *ProjectsAPI) GetWebhook(projectKey string, webhookID int) (*backlog.Webhook, *backlog.Response, error) {
+	if m.GetWebhookFunc == nil {
+		panic("backlogmock: ProjectsAPI.GetWebhookFunc is not set")
+	}
+	return m.GetWebhookFunc(projectKey, webhookID)
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (m *ProjectsAPI) CreateWebhook(projectKey string, request backlog.WebhookRequest) (*backlog.Webhook, *backlog.Response, error) {
+	if m.CreateWebhookFunc == nil {
+		panic("backlogmock: ProjectsAPI.CreateWebhookFunc is not set")
+	}
+	return m.CreateWebhookFunc(projectKey, request)
+}
+
+// UpdateWebhook calls UpdateWebhookFunc.
+func (m *ProjectsAPI) UpdateWebhook(projectKey string, webhookID int, request backlog.WebhookRequest) (*backlog.Webhook, *backlog.Response, error) {
+	if m.UpdateWebhookFunc == nil {
+		panic("backlogmock: ProjectsAPI.UpdateWebhookFunc is not set")
+	}
+	return m.UpdateWebhookFunc(projectKey, webhookID, request)
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (m *ProjectsAPI) DeleteWebhook(projectKey string, webhookID int) (*backlog.Webhook, *backlog.Response, error) {
+	if m.DeleteWebhookFunc == nil {
+		panic("backlogmock: ProjectsAPI.DeleteWebhookFunc is not set")
+	}
+	return m.DeleteWebhookFunc(projectKey, webhookID)
+}
+
+// GetMetadata calls GetMetadataFunc.
+func (m *ProjectsAPI) GetMetadata(projectKeys []string, opt *backlog.BulkOptions) []backlog.ProjectMetadata {
+	if m.GetMetadataFunc == nil {
+		panic("backlogmock: ProjectsAPI.GetMetadataFunc is not set")
+	}
+	return m.GetMetadataFunc(projectKeys, opt)
+}
+
+// ListActivities calls ListActivitiesFunc.
+func (m *ProjectsAPI) ListActivities(projectKey string, request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error) {
+	if m.ListActivitiesFunc == nil {
+		panic("backlogmock: ProjectsAPI.ListActivitiesFunc is not set")
+	}
+	return m.ListActivitiesFunc(projectKey, request)
+}
+
+// SpaceAPI is a mock of backlog.SpaceAPI.
+type SpaceAPI struct {
+	ListPrioritiesFunc     func() ([]*backlog.Priority, *backlog.Response, error)
+	ListStatusesFunc       func() ([]*backlog.Status, *backlog.Response, error)
+	ListResolutionsFunc    func() ([]*backlog.Resolution, *backlog.Response, error)
+	GetFunc                func() (*backlog.Space, *backlog.Response, error)
+	GetNotificationFunc    func() (*backlog.SpaceNotification, *backlog.Response, error)
+	UpdateNotificationFunc func(content string) (*backlog.SpaceNotification, *backlog.Response, error)
+	GetDiskUsageFunc       func() (*backlog.DiskUsage, *backlog.Response, error)
+	GetLicenceFunc         func() (*backlog.Licence, *backlog.Response, error)
+	DownloadLogoFunc       func(w io.Writer) (string, *backlog.Response, error)
+	RateLimitFunc          func() (*backlog.RateLimits, *backlog.Response, error)
+	CheckRateLimitFunc     func(bucket backlog.RateLimitBucket, n int) (*backlog.RateLimit, *backlog.Response, error)
+	ListActivitiesFunc     func(request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error)
+}
+
+// ListPriorities calls ListPrioritiesFunc.
+func (m *SpaceAPI) ListPriorities() ([]*backlog.Priority, *backlog.Response, error) {
+	if m.ListPrioritiesFunc == nil {
+		panic("backlogmock: SpaceAPI.ListPrioritiesFunc is not set")
+	}
+	return m.ListPrioritiesFunc()
+}
+
+// ListStatuses calls ListStatusesFunc.
+func (m *SpaceAPI) ListStatuses() ([]*backlog.Status, *backlog.Response, error) {
+	if m.ListStatusesFunc == nil {
+		panic("backlogmock: SpaceAPI.ListStatusesFunc is not set")
+	}
+	return m.ListStatusesFunc()
+}
+
+// ListResolutions calls ListResolutionsFunc.
+func (m *SpaceAPI) ListResolutions() ([]*backlog.Resolution, *backlog.Response, error) {
+	if m.ListResolutionsFunc == nil {
+		panic("backlogmock: SpaceAPI.ListResolutionsFunc is not set")
+	}
+	return m.ListResolutionsFunc()
+}
+
+// Get calls GetFunc.
+func (m *SpaceAPI) Get() (*backlog.Space, *backlog.Response, error) {
+	if m.GetFunc == nil {
+		panic("backlogmock: SpaceAPI.GetFunc is not set")
+	}
+	return m.GetFunc()
+}
+
+// GetNotification calls GetNotificationFunc.
+func (m *SpaceAPI) GetNotification() (*backlog.SpaceNotification, *backlog.Response, error) {
+	if m.GetNotificationFunc == nil {
+		panic("backlogmock: SpaceAPI.GetNotificationFunc is not set")
+	}
+	return m.GetNotificationFunc()
+}
+
+// UpdateNotification calls UpdateNotificationFunc.
+func (m *SpaceAPI) UpdateNotification(content string) (*backlog.SpaceNotification, *backlog.Response, error) {
+	if m.UpdateNotificationFunc == nil {
+		panic("backlogmock: SpaceAPI.UpdateNotificationFunc is not set")
+	}
+	return m.UpdateNotificationFunc(content)
+}
+
+// GetDiskUsage calls GetDiskUsageFunc.
+func (m *SpaceAPI) GetDiskUsage() (*backlog.DiskUsage, *backlog.Response, error) {
+	if m.GetDiskUsageFunc == nil {
+		panic("backlogmock: SpaceAPI.GetDiskUsageFunc is not set")
+	}
+	return m.GetDiskUsageFunc()
+}
+
+// GetLicence calls GetLicenceFunc.
+func (m *SpaceAPI) GetLicence() (*backlog.Licence, *backlog.Response, error) {
+	if m.GetLicenceFunc == nil {
+		panic("backlogmock: SpaceAPI.GetLicenceFunc is not set")
+	}
+	return m.GetLicenceFunc()
+}
+
+// DownloadLogo calls DownloadLogoFunc.
+func (m *SpaceAPI) DownloadLogo(w io.Writer) (string, *backlog.Response, error) {
+	if m.DownloadLogoFunc == nil {
+		panic("backlogmock: SpaceAPI.DownloadLogoFunc is not set")
+	}
+	return m.DownloadLogoFunc(w)
+}
+
+// RateLimit calls RateLimitFunc.
+func (m *SpaceAPI) RateLimit() (*backlog.RateLimits, *backlog.Response, error) {
+	if m.RateLimitFunc == nil {
+		panic("backlogmock: SpaceAPI.RateLimitFunc is not set")
+	}
+	return m.RateLimitFunc()
+}
+
+// CheckRateLimit calls CheckRateLimitFunc.
+func (m *SpaceAPI) CheckRateLimit(bucket backlog.RateLimitBucket, n int) (*backlog.RateLimit, *backlog.Response, error) {
+	if m.CheckRateLimitFunc == nil {
+		panic("backlogmock: SpaceAPI.CheckRateLimitFunc is not set")
+	}
+	return m.CheckRateLimitFunc(bucket, n)
+}
+
+// ListActivities calls ListActivitiesFunc.
+func (m *SpaceAPI) ListActivities(request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error) {
+	if m.ListActivitiesFunc == nil {
+		panic("backlogmock: SpaceAPI.ListActivitiesFunc is not set")
+	}
+	return m.ListActivitiesFunc(request)
+}
+
+// UsersAPI is a mock of backlog.UsersAPI.
+type UsersAPI struct {
+	ListActivitiesFunc func(userID int, request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error)
+	MyselfFunc         func() (*backlog.User, *backlog.Response, error)
+}
+
+// ListActivities calls ListActivitiesFunc.
+func (m *UsersAPI) ListActivities(userID int, request backlog.ActivityListRequest) ([]*backlog.Activity, *backlog.Response, error) {
+	if m.ListActivitiesFunc == nil {
+		panic("backlogmock: UsersAPI.ListActivitiesFunc is not set")
+	}
+	return m.ListActivitiesFunc(userID, request)
+}
+
+// Myself calls MyselfFunc.
+func (m *UsersAPI) Myself() (*backlog.User, *backlog.Response, error) {
+	if m.MyselfFunc == nil {
+		panic("backlogmock: UsersAPI.MyselfFunc is not set")
+	}
+	return m.MyselfFunc()
+}
+
+// NotificationsAPI is a mock of backlog.NotificationsAPI.
+type NotificationsAPI struct {
+	ListFunc             func(request backlog.NotificationListRequest) ([]*backlog.Notification, *backlog.Response, error)
+	CountFunc            func(request backlog.NotificationCountRequest) (int, *backlog.Response, error)
+	ResetUnreadCountFunc func() (int, *backlog.Response, error)
+	MarkAsReadFunc       func(notificationID int) (*backlog.Response, error)
+}
+
+// List calls ListFunc.
+func (m *NotificationsAPI) List(request backlog.NotificationListRequest) ([]*backlog.Notification, *backlog.Response, error) {
+	if m.ListFunc == nil {
+		panic("backlogmock: NotificationsAPI.ListFunc is not set")
+	}
+	return m.ListFunc(request)
+}
+
+// Count calls CountFunc.
+func (m *NotificationsAPI) Count(request backlog.NotificationCountRequest) (int, *backlog.Response, error) {
+	if m.CountFunc == nil {
+		panic("backlogmock: NotificationsAPI.CountFunc is not set")
+	}
+	return m.CountFunc(request)
+}
+
+// ResetUnreadCount calls ResetUnreadCountFunc.
+func (m *NotificationsAPI) ResetUnreadCount() (int, *backlog.Response, error) {
+	if m.ResetUnreadCountFunc == nil {
+		panic("backlogmock: NotificationsAPI.ResetUnreadCountFunc is not set")
+	}
+	return m.ResetUnreadCountFunc()
+}
+
+// MarkAsRead calls MarkAsReadFunc.
+func (m *NotificationsAPI) MarkAsRead(notificationID int) (*backlog.Response, error) {
+	if m.MarkAsReadFunc == nil {
+		panic("backlogmock: NotificationsAPI.MarkAsReadFunc is not set")
+	}
+	return m.MarkAsReadFunc(notificationID)
+}
+
+// WatchingsAPI is a mock of backlog.WatchingsAPI.
+type WatchingsAPI struct {
+	ListFunc       func(userID int, request backlog.WatchingListRequest) ([]*backlog.Watching, *backlog.Response, error)
+	CountFunc      func(userID int, request backlog.WatchingCountRequest) (int, *backlog.Response, error)
+	GetFunc        func(watchingID int) (*backlog.Watching, *backlog.Response, error)
+	AddFunc        func(issueIDOrKey string, note string) (*backlog.Watching, *backlog.Response, error)
+	UpdateFunc     func(watchingID int, note string) (*backlog.Watching, *backlog.Response, error)
+	DeleteFunc     func(watchingID int) (*backlog.Watching, *backlog.Response, error)
+	MarkAsReadFunc func(watchingID int) (*backlog.Response, error)
+}
+
+// List calls ListFunc.
+func (m *WatchingsAPI) List(userID int, request backlog.WatchingListRequest) ([]*backlog.Watching, *backlog.Response, error) {
+	if m.ListFunc == nil {
+		panic("backlogmock: WatchingsAPI.ListFunc is not set")
+	}
+	return m.ListFunc(userID, request)
+}
+
+// Count calls CountFunc.
+func (m *WatchingsAPI) Count(userID int, request backlog.WatchingCountRequest) (int, *backlog.Response, error) {
+	if m.CountFunc == nil {
+		panic("backlogmock: WatchingsAPI.CountFunc is not set")
+	}
+	return m.CountFunc(userID, request)
+}
+
+// Get calls GetFunc.
+func (m *WatchingsAPI) Get(watchingID int) (*backlog.Watching, *backlog.Response, error) {
+	if m.GetFunc == nil {
+		panic("backlogmock: WatchingsAPI.GetFunc is not set")
+	}
+	return m.GetFunc(watchingID)
+}
+
+// Add calls AddFunc.
+func (m *WatchingsAPI) Add(issueIDOrKey string, note string) (*backlog.Watching, *backlog.Response, error) {
+	if m.AddFunc == nil {
+		panic("backlogmock: WatchingsAPI.AddFunc is not set")
+	}
+	return m.AddFunc(issueIDOrKey, note)
+}
+
+// Update calls UpdateFunc.
+func (m *WatchingsAPI) Update(watchingID int, note string) (*backlog.Watching, *backlog.Response, error) {
+	if m.UpdateFunc == nil {
+		panic("backlogmock: WatchingsAPI.UpdateFunc is not set")
+	}
+	return m.UpdateFunc(watchingID, note)
+}
+
+// Delete calls DeleteFunc.
+func (m *WatchingsAPI) Delete(watchingID int) (*backlog.Watching, *backlog.Response, error) {
+	if m.DeleteFunc == nil {
+		panic("backlogmock: WatchingsAPI.DeleteFunc is not set")
+	}
+	return m.DeleteFunc(watchingID)
+}
+
+// MarkAsRead calls MarkAsReadFunc.
+func (m *WatchingsAPI) MarkAsRead(watchingID int) (*backlog.Response, error) {
+	if m.MarkAsReadFunc == nil {
+		panic("backlogmock: WatchingsAPI.MarkAsReadFunc is not set")
+	}
+	return m.MarkAsReadFunc(watchingID)
+}
+
+// StarsAPI is a mock of backlog.StarsAPI.
+type StarsAPI struct {
+	AddFunc           func(request backlog.StarRequest) (*backlog.Response, error)
+	RemoveFunc        func(starID int) (*backlog.Response, error)
+	ListReceivedFunc  func(userID int, request backlog.StarListRequest) ([]*backlog.Star, *backlog.Response, error)
+	CountReceivedFunc func(userID int, request backlog.StarCountRequest) (int, *backlog.Response, error)
+}
+
+// Add calls AddFunc.
+func (m *StarsAPI) Add(request backlog.StarRequest) (*backlog.Response, error) {
+	if m.AddFunc == nil {
+		panic("backlogmock: StarsAPI.AddFunc is not set")
+	}
+	return m.AddFunc(request)
+}
+
+// Remove calls RemoveFunc.
+func (m *StarsAPI) Remove(starID int) (*backlog.Response, error) {
+	if m.RemoveFunc == nil {
+		panic("backlogmock: StarsAPI.RemoveFunc is not set")
+	}
+	return m.RemoveFunc(starID)
+}
+
+// ListReceived calls ListReceivedFunc.
+func (m *StarsAPI) ListReceived(userID int, request backlog.StarListRequest) ([]*backlog.Star, *backlog.Response, error) {
+	if m.ListReceivedFunc == nil {
+		panic("backlogmock: StarsAPI.ListReceivedFunc is not set")
+	}
+	return m.ListReceivedFunc(userID, request)
+}
+
+// CountReceived calls CountReceivedFunc.
+func (m *StarsAPI) CountReceived(userID int, request backlog.StarCountRequest) (int, *backlog.Response, error) {
+	if m.CountReceivedFunc == nil {
+		panic("backlogmock: StarsAPI.CountReceivedFunc is not set")
+	}
+	return m.CountReceivedFunc(userID, request)
+}
+
+// DocumentsAPI is a mock of backlog.DocumentsAPI.
+type DocumentsAPI struct {
+	ListFunc               func(request backlog.DocumentListRequest) ([]*backlog.Document, *backlog.Response, error)
+	TreeFunc               func(projectKey string) (*backlog.DocumentTree, *backlog.Response, error)
+	GetFunc                func(documentID string) (*backlog.Document, *backlog.Response, error)
+	DownloadAttachmentFunc func(documentID string, attachmentID int, w io.Writer) (string, *backlog.Response, error)
+}
+
+// List calls ListFunc.
+func (m *DocumentsAPI) List(request backlog.DocumentListRequest) ([]*backlog.Document, *backlog.Response, error) {
+	if m.ListFunc == nil {
+		panic("backlogmock: DocumentsAPI.ListFunc is not set")
+	}
+	return m.ListFunc(request)
+}
+
+// Tree calls TreeFunc.
+func (m *DocumentsAPI) Tree(projectKey string) (*backlog.DocumentTree, *backlog.Response, error) {
+	if m.TreeFunc == nil {
+		panic("backlogmock: DocumentsAPI.TreeFunc is not set")
+	}
+	return m.TreeFunc(projectKey)
+}
+
+// Get calls GetFunc.
+func (m *DocumentsAPI) Get(documentID string) (*backlog.Document, *backlog.Response, error) {
+	if m.GetFunc == nil {
+		panic("backlogmock: DocumentsAPI.GetFunc is not set")
+	}
+	return m.GetFunc(documentID)
+}
+
+// DownloadAttachment calls DownloadAttachmentFunc.
+func (m *DocumentsAPI) DownloadAttachment(documentID string, attachmentID int, w io.Writer) (string, *backlog.Response, error) {
+	if m.DownloadAttachmentFunc == nil {
+		panic("backlogmock: DocumentsAPI.DownloadAttachmentFunc is not set")
+	}
+	return m.DownloadAttachmentFunc(documentID, attachmentID, w)
+}
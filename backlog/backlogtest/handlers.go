@@ -0,0 +1,727 @@
+package backlogtest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+func (s *Server) serveSpace(w http.ResponseWriter, r *request) {
+	switch {
+	case r.match("GET", "space"):
+		writeJSON(w, http.StatusOK, s.space)
+	case r.match("GET", "priorities"):
+		writeJSON(w, http.StatusOK, s.priorities)
+	case r.match("GET", "statuses"):
+		writeJSON(w, http.StatusOK, s.statuses)
+	case r.match("GET", "resolutions"):
+		writeJSON(w, http.StatusOK, s.resolutions)
+	case r.match("GET", "users"):
+		writeJSON(w, http.StatusOK, nonNil(s.users))
+	case r.match("GET", "users", "myself"):
+		if s.myself == nil {
+			notFound(w, "No user.")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.myself)
+	case r.match("GET", "users", "*"):
+		id, _ := strconv.Atoi(r.path[1])
+		user := s.findUser(id)
+		if user == nil {
+			notFound(w, "No user.")
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	default:
+		notFound(w, "No such API.")
+	}
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *request) {
+	if r.match("GET", "projects") {
+		projects := make([]backlog.Project, 0, len(s.projects))
+		for _, p := range s.projects {
+			projects = append(projects, p.Project)
+		}
+		writeJSON(w, http.StatusOK, projects)
+		return
+	}
+	if len(r.path) < 2 {
+		notFound(w, "No such API.")
+		return
+	}
+	p := s.findProject(r.path[1])
+	if p == nil {
+		notFound(w, "No project.")
+		return
+	}
+
+	switch {
+	case r.match("GET", "projects", "*"):
+		writeJSON(w, http.StatusOK, p.Project)
+
+	case r.match("GET", "projects", "*", "issueTypes"):
+		writeJSON(w, http.StatusOK, nonNil(p.issueTypes))
+	case r.match("POST", "projects", "*", "issueTypes"):
+		name, color := r.form.Get("name"), r.form.Get("color")
+		if len(name) == 0 || len(color) == 0 {
+			badRequest(w, "Please input name and color.")
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.addIssueType(p, name, color))
+	case r.match("DELETE", "projects", "*", "issueTypes", "*"):
+		s.deleteIssueType(w, r, p)
+
+	case r.match("GET", "projects", "*", "categories"):
+		writeJSON(w, http.StatusOK, nonNil(p.categories))
+	case r.match("POST", "projects", "*", "categories"):
+		name := r.form.Get("name")
+		if len(name) == 0 {
+			badRequest(w, "Please input name.")
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.addCategory(p, name))
+	case r.match("DELETE", "projects", "*", "categories", "*"):
+		id, _ := strconv.Atoi(r.path[3])
+		for i, c := range p.categories {
+			if c.ID == id {
+				p.categories = append(p.categories[:i], p.categories[i+1:]...)
+				for _, issue := range s.issues {
+					issue.Categories = removeCategory(issue.Categories, id)
+				}
+				writeJSON(w, http.StatusOK, c)
+				return
+			}
+		}
+		notFound(w, "No category.")
+
+	case r.match("GET", "projects", "*", "versions"):
+		writeJSON(w, http.StatusOK, nonNil(p.versions))
+
+	case r.match("GET", "projects", "*", "users"):
+		users := []*backlog.User{}
+		for _, id := range p.userIDs {
+			users = append(users, s.findUser(id))
+		}
+		writeJSON(w, http.StatusOK, users)
+
+	default:
+		notFound(w, "No such API.")
+	}
+}
+
+func (s *Server) deleteIssueType(w http.ResponseWriter, r *request, p *project) {
+	id, _ := strconv.Atoi(r.path[3])
+	index := -1
+	for i, t := range p.issueTypes {
+		if t.ID == id {
+			index = i
+		}
+	}
+	if index < 0 {
+		notFound(w, "No issue type.")
+		return
+	}
+	substituteID, ok := formInt(r.form, "substituteIssueTypeId")
+	if !ok {
+		badRequest(w, "Please input substituteIssueTypeId.")
+		return
+	}
+	substitute := p.issueType(substituteID)
+	if substitute == nil || substituteID == id {
+		badRequest(w, "Invalid substituteIssueTypeId.")
+		return
+	}
+
+	deleted := p.issueTypes[index]
+	p.issueTypes = append(p.issueTypes[:index], p.issueTypes[index+1:]...)
+	for _, issue := range s.issues {
+		if issue.ProjectID == p.ID && issue.IssueType.ID == id {
+			issue.IssueType = *substitute
+		}
+	}
+	writeJSON(w, http.StatusOK, deleted)
+}
+
+func (s *Server) serveIssues(w http.ResponseWriter, r *request) {
+	switch {
+	case r.match("GET", "issues"):
+		s.searchIssues(w, r)
+		return
+	case r.match("POST", "issues"):
+		s.createIssue(w, r)
+		return
+	case len(r.path) < 2:
+		notFound(w, "No such API.")
+		return
+	}
+
+	issue := s.findIssue(r.path[1])
+	if issue == nil {
+		notFound(w, "No issue.")
+		return
+	}
+
+	switch {
+	case r.match("GET", "issues", "*"):
+		writeJSON(w, http.StatusOK, issue)
+	case r.match("PATCH", "issues", "*"):
+		s.editIssue(w, r, issue)
+	case r.match("DELETE", "issues", "*"):
+		for i, x := range s.issues {
+			if x == issue {
+				s.issues = append(s.issues[:i], s.issues[i+1:]...)
+				break
+			}
+		}
+		delete(s.comments, issue.ID)
+		// Backlog turns the child issues into normal issues.
+		for _, x := range s.issues {
+			if x.ParentIssueID != nil && *x.ParentIssueID == issue.ID {
+				x.ParentIssueID = nil
+			}
+		}
+		writeJSON(w, http.StatusOK, issue)
+
+	case r.match("GET", "issues", "*", "comments"):
+		comments := append([]*backlog.IssueComment{}, s.comments[issue.ID]...)
+		if r.query.Get("order") != "asc" {
+			for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+				comments[i], comments[j] = comments[j], comments[i]
+			}
+		}
+		writeJSON(w, http.StatusOK, comments)
+	case r.match("POST", "issues", "*", "comments"):
+		content := r.form.Get("content")
+		if len(content) == 0 {
+			badRequest(w, "Please input content.")
+			return
+		}
+		issue.Updated = s.now()
+		writeJSON(w, http.StatusCreated, s.addComment(issue, content, nil))
+
+	default:
+		notFound(w, "No such API.")
+	}
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *request) {
+	projectID, ok := formInt(r.form, "projectId")
+	if !ok {
+		badRequest(w, "Please input projectId.")
+		return
+	}
+	p := s.findProjectByID(projectID)
+	if p == nil {
+		notFound(w, "No project.")
+		return
+	}
+	if len(strings.TrimSpace(r.form.Get("summary"))) == 0 {
+		badRequest(w, "Please input summary.")
+		return
+	}
+	if _, ok := formInt(r.form, "issueTypeId"); !ok {
+		badRequest(w, "Please input issueTypeId.")
+		return
+	}
+	if _, ok := formInt(r.form, "priorityId"); !ok {
+		badRequest(w, "Please input priorityId.")
+		return
+	}
+
+	issue := &backlog.Issue{}
+	issue.Status.ID, issue.Status.Name = 1, "Open"
+	if s.myself != nil {
+		issue.CreatedUser.ID, issue.CreatedUser.UserID, issue.CreatedUser.Name = s.myself.ID, s.myself.UserID, s.myself.Name
+	}
+	if _, msg := s.applyIssueForm(p, issue, r.form); len(msg) > 0 {
+		badRequest(w, msg)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.addIssue(p, issue))
+}
+
+func (s *Server) editIssue(w http.ResponseWriter, r *request, issue *backlog.Issue) {
+	p := s.findProjectByID(issue.ProjectID)
+	edited := *issue
+	changes, msg := s.applyIssueForm(p, &edited, r.form)
+	if len(msg) > 0 {
+		badRequest(w, msg)
+		return
+	}
+	edited.Updated = s.now()
+	*issue = edited
+	if comment := r.form.Get("comment"); len(comment) > 0 || len(changes) > 0 {
+		s.addComment(issue, comment, changes)
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
+
+// applyIssueForm sets the fields in form to issue, and returns the change logs
+// or the message of the error if form is invalid.
+func (s *Server) applyIssueForm(p *project, issue *backlog.Issue, form map[string][]string) ([]backlog.ChangeLog, string) {
+	var changes []backlog.ChangeLog
+	change := func(field, original, value string) {
+		if original != value {
+			changes = append(changes, backlog.ChangeLog{Field: field, NewValue: value, OriginalValue: original})
+		}
+	}
+	get := func(key string) (string, bool) {
+		v, ok := form[key]
+		if !ok || len(v) == 0 {
+			return "", false
+		}
+		return v[0], true
+	}
+	getInt := func(key string) (int, bool, string) {
+		v, ok := get(key)
+		if !ok {
+			return 0, false, ""
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false, "Invalid " + key + "."
+		}
+		return i, true, ""
+	}
+
+	if v, ok := get("summary"); ok {
+		if len(strings.TrimSpace(v)) == 0 {
+			return nil, "Please input summary."
+		}
+		change("summary", issue.Summary, v)
+		issue.Summary = v
+	}
+	if v, ok := get("description"); ok {
+		change("description", issue.Description, v)
+		issue.Description = v
+	}
+	if id, ok, msg := getInt("issueTypeId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		t := p.issueType(id)
+		if t == nil {
+			return nil, "No issue type."
+		}
+		change("issueType", issue.IssueType.Name, t.Name)
+		issue.IssueType = *t
+	}
+	if id, ok, msg := getInt("priorityId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		priority := s.priority(id)
+		if priority == nil {
+			return nil, "No priority."
+		}
+		change("priority", issue.Priority.Name, priority.Name)
+		issue.Priority = *priority
+	}
+	if id, ok, msg := getInt("statusId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		status := s.status(id)
+		if status == nil {
+			return nil, "No status."
+		}
+		change("status", issue.Status.Name, status.Name)
+		issue.Status.ID, issue.Status.Name = status.ID, status.Name
+	}
+	if id, ok, msg := getInt("resolutionId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		resolution := s.resolution(id)
+		if resolution == nil {
+			return nil, "No resolution."
+		}
+		original := ""
+		if issue.Resolution != nil {
+			original = issue.Resolution.Name
+		}
+		change("resolution", original, resolution.Name)
+		copied := *resolution
+		issue.Resolution = &copied
+	}
+	if id, ok, msg := getInt("assigneeId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		user := s.findUser(id)
+		if user == nil || !p.hasUser(id) {
+			return nil, "No assignee."
+		}
+		change("assigner", issue.Assignee.Name, user.Name)
+		issue.Assignee.ID, issue.Assignee.Name = user.ID, user.Name
+	}
+	if id, ok, msg := getInt("parentIssueId"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		parent := s.findIssue(strconv.Itoa(id))
+		if parent == nil || parent.ProjectID != p.ID || parent.ID == issue.ID {
+			return nil, "Invalid parentIssueId."
+		}
+		issue.ParentIssueID = &id
+	}
+	if id, ok, msg := getInt("categoryId[]"); len(msg) > 0 {
+		return nil, msg
+	} else if ok {
+		c := p.category(id)
+		if c == nil {
+			return nil, "No category."
+		}
+		issue.Categories = []backlog.Category{*c}
+	}
+	for _, key := range []string{"versionId[]", "milestoneId[]"} {
+		id, ok, msg := getInt(key)
+		if len(msg) > 0 {
+			return nil, msg
+		}
+		if !ok {
+			continue
+		}
+		v := p.version(id)
+		if v == nil {
+			return nil, "No version."
+		}
+		if key == "versionId[]" {
+			issue.Versions = []backlog.Version{*v}
+		} else {
+			issue.Milestones = []backlog.Version{*v}
+		}
+	}
+	for _, key := range []string{"startDate", "dueDate"} {
+		v, ok := get(key)
+		if !ok {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, "Invalid " + key + "."
+		}
+		if key == "startDate" {
+			issue.StartDate = date
+		} else {
+			issue.DueDate = date
+		}
+	}
+	return changes, ""
+}
+
+// searchParams are the parameters of GET issues supported by searchIssues.
+// Requests with other parameters fail, so that tests do not get unfiltered
+// results which look right.
+var searchParams = map[string]bool{
+	"apiKey": true, "count": true, "offset": true, "sort": true, "order": true, "keyword": true, "parentChild": true,
+	"id[]": true, "projectId[]": true, "statusId[]": true, "priorityId[]": true, "issueTypeId[]": true,
+	"assigneeId[]": true, "createdUserId[]": true, "resolutionId[]": true, "parentIssueId[]": true,
+	"categoryId[]": true, "versionId[]": true, "milestoneId[]": true,
+	"startDateSince": true, "startDateUntil": true, "dueDateSince": true, "dueDateUntil": true,
+	"createdSince": true, "createdUntil": true, "updatedSince": true, "updatedUntil": true,
+}
+
+// issueSorts are the sort keys supported by searchIssues.
+var issueSorts = map[string]func(a, b *backlog.Issue) bool{
+	"issueType": func(a, b *backlog.Issue) bool { return a.IssueType.Name < b.IssueType.Name },
+	"summary":   func(a, b *backlog.Issue) bool { return a.Summary < b.Summary },
+	"status":    func(a, b *backlog.Issue) bool { return a.Status.ID < b.Status.ID },
+	"priority":  func(a, b *backlog.Issue) bool { return a.Priority.ID < b.Priority.ID },
+	"assignee":  func(a, b *backlog.Issue) bool { return a.Assignee.Name < b.Assignee.Name },
+	"created":   func(a, b *backlog.Issue) bool { return a.Created.Before(b.Created) },
+	"updated":   func(a, b *backlog.Issue) bool { return a.Updated.Before(b.Updated) },
+	"startDate": func(a, b *backlog.Issue) bool { return a.StartDate.Before(b.StartDate) },
+	"dueDate":   func(a, b *backlog.Issue) bool { return a.DueDate.Before(b.DueDate) },
+	"createdUser": func(a, b *backlog.Issue) bool {
+		return a.CreatedUser.Name < b.CreatedUser.Name
+	},
+}
+
+func (s *Server) searchIssues(w http.ResponseWriter, r *request) {
+	for key := range r.query {
+		if !searchParams[key] {
+			badRequest(w, "backlogtest does not support "+key+".")
+			return
+		}
+	}
+
+	count, offset := 20, 0
+	if v := r.query.Get("count"); len(v) > 0 {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			badRequest(w, "count must be between 1 and 100.")
+			return
+		}
+		count = n
+	}
+	if v := r.query.Get("offset"); len(v) > 0 {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			badRequest(w, "Invalid offset.")
+			return
+		}
+		offset = n
+	}
+
+	filters := map[string]func(*backlog.Issue) []int{
+		"id[]":          func(i *backlog.Issue) []int { return []int{i.ID} },
+		"projectId[]":   func(i *backlog.Issue) []int { return []int{i.ProjectID} },
+		"statusId[]":    func(i *backlog.Issue) []int { return []int{i.Status.ID} },
+		"priorityId[]":  func(i *backlog.Issue) []int { return []int{i.Priority.ID} },
+		"issueTypeId[]": func(i *backlog.Issue) []int { return []int{i.IssueType.ID} },
+		"assigneeId[]":  func(i *backlog.Issue) []int { return []int{i.Assignee.ID} },
+		"createdUserId[]": func(i *backlog.Issue) []int {
+			return []int{i.CreatedUser.ID}
+		},
+		"resolutionId[]": func(i *backlog.Issue) []int {
+			if i.Resolution == nil {
+				return nil
+			}
+			return []int{i.Resolution.ID}
+		},
+		"parentIssueId[]": func(i *backlog.Issue) []int {
+			if i.ParentIssueID == nil {
+				return nil
+			}
+			return []int{*i.ParentIssueID}
+		},
+		"categoryId[]": func(i *backlog.Issue) []int {
+			ids := []int{}
+			for _, c := range i.Categories {
+				ids = append(ids, c.ID)
+			}
+			return ids
+		},
+		"versionId[]": func(i *backlog.Issue) []int {
+			ids := []int{}
+			for _, v := range i.Versions {
+				ids = append(ids, v.ID)
+			}
+			return ids
+		},
+		"milestoneId[]": func(i *backlog.Issue) []int {
+			ids := []int{}
+			for _, v := range i.Milestones {
+				ids = append(ids, v.ID)
+			}
+			return ids
+		},
+	}
+
+	// Dates are compared by day in UTC, and the bounds are inclusive.
+	dates := map[string]func(*backlog.Issue) time.Time{
+		"startDate": func(i *backlog.Issue) time.Time { return i.StartDate },
+		"dueDate":   func(i *backlog.Issue) time.Time { return i.DueDate },
+		"created":   func(i *backlog.Issue) time.Time { return i.Created },
+		"updated":   func(i *backlog.Issue) time.Time { return i.Updated },
+	}
+	type dateRange struct {
+		date         func(*backlog.Issue) time.Time
+		since, until string
+	}
+	var ranges []dateRange
+	for name, date := range dates {
+		rng := dateRange{date: date, since: r.query.Get(name + "Since"), until: r.query.Get(name + "Until")}
+		for key, v := range map[string]string{name + "Since": rng.since, name + "Until": rng.until} {
+			if _, err := time.Parse("2006-01-02", v); len(v) > 0 && err != nil {
+				badRequest(w, "Invalid "+key+".")
+				return
+			}
+		}
+		if len(rng.since) > 0 || len(rng.until) > 0 {
+			ranges = append(ranges, rng)
+		}
+	}
+
+	parentChild := 0
+	if v := r.query.Get("parentChild"); len(v) > 0 {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 4 {
+			badRequest(w, "Invalid parentChild.")
+			return
+		}
+		parentChild = n
+	}
+	hasChildren := map[int]bool{}
+	for _, issue := range s.issues {
+		if issue.ParentIssueID != nil {
+			hasChildren[*issue.ParentIssueID] = true
+		}
+	}
+
+	less := func(a, b *backlog.Issue) bool { return false }
+	if key := r.query.Get("sort"); len(key) > 0 {
+		fn, ok := issueSorts[key]
+		if !ok {
+			badRequest(w, "backlogtest does not support sort="+key+".")
+			return
+		}
+		less = fn
+	}
+	keyword := strings.ToLower(r.query.Get("keyword"))
+
+	issues := []*backlog.Issue{}
+	for _, issue := range s.issues {
+		ok := true
+		for key, fn := range filters {
+			if values, exists := r.query[key]; exists && !intersects(values, fn(issue)) {
+				ok = false
+			}
+		}
+		for _, rng := range ranges {
+			date := rng.date(issue)
+			day := date.UTC().Format("2006-01-02")
+			if date.IsZero() || (len(rng.since) > 0 && day < rng.since) || (len(rng.until) > 0 && day > rng.until) {
+				ok = false
+			}
+		}
+		isChild, isParent := issue.ParentIssueID != nil, hasChildren[issue.ID]
+		switch parentChild {
+		case 1: // 子課題以外
+			ok = ok && !isChild
+		case 2: // 子課題
+			ok = ok && isChild
+		case 3: // 親課題でも子課題でもない
+			ok = ok && !isChild && !isParent
+		case 4: // 親課題
+			ok = ok && isParent
+		}
+		if len(keyword) > 0 && !strings.Contains(strings.ToLower(issue.Summary+" "+issue.Description), keyword) {
+			ok = false
+		}
+		if ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	asc := r.query.Get("order") == "asc"
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if !asc {
+			a, b = b, a
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return a.ID < b.ID
+	})
+
+	if offset > len(issues) {
+		offset = len(issues)
+	}
+	issues = issues[offset:]
+	if count < len(issues) {
+		issues = issues[:count]
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func intersects(values []string, ids []int) bool {
+	for _, v := range values {
+		for _, id := range ids {
+			if v == strconv.Itoa(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func removeCategory(categories []backlog.Category, id int) []backlog.Category {
+	kept := []backlog.Category{}
+	for _, c := range categories {
+		if c.ID != id {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// nonNil returns v, which is encoded as [] instead of null if empty.
+func nonNil(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []*backlog.User:
+		if v == nil {
+			return []*backlog.User{}
+		}
+	case []*backlog.IssueType:
+		if v == nil {
+			return []*backlog.IssueType{}
+		}
+	case []*backlog.Category:
+		if v == nil {
+			return []*backlog.Category{}
+		}
+	case []*backlog.Version:
+		if v == nil {
+			return []*backlog.Version{}
+		}
+	}
+	return v
+}
+
+func (s *Server) priority(id int) *backlog.Priority {
+	for _, p := range s.priorities {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) status(id int) *backlog.Status {
+	for _, st := range s.statuses {
+		if st.ID == id {
+			return st
+		}
+	}
+	return nil
+}
+
+func (s *Server) resolution(id int) *backlog.Resolution {
+	for _, r := range s.resolutions {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (p *project) issueType(id int) *backlog.IssueType {
+	for _, t := range p.issueTypes {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (p *project) category(id int) *backlog.Category {
+	for _, c := range p.categories {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (p *project) version(id int) *backlog.Version {
+	for _, v := range p.versions {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p *project) hasUser(id int) bool {
+	for _, u := range p.userIDs {
+		if u == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,346 @@
+package backlogtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// Mode is the mode of Recorder.
+type Mode int
+
+const (
+	// Replay serves the recorded responses without accessing the network.
+	Replay Mode = iota
+	// Record sends the requests to Backlog and records the interactions.
+	Record
+)
+
+// Interaction is a recorded pair of a request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request in a golden file. The apiKey parameter, the
+// Authorization header and OAuth tokens are redacted.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response in a golden file.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"bodyBase64,omitempty"` // Body is base64 encoded since it is not UTF-8
+}
+
+// Recorder is an http.RoundTripper which records the interactions with
+// Backlog to a golden file, and replays them in tests.
+//
+//	recorder, err := backlogtest.NewRecorder("testdata/issues.json", backlogtest.Replay)
+//	client := backlog.NewClient(recorder.Client(), "example", "apikey")
+//	...
+//	if err := recorder.Close(); err != nil { ... } // saves the file in Record mode
+//
+// In Replay mode, requests are matched by the method, the path and the
+// query without the apiKey parameter and the OAuth parameters, in the order
+// they were recorded.
+// A request without a match fails with *UnmatchedRequestError.
+type Recorder struct {
+	// Transport sends the requests in Record mode. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	mode Mode
+	path string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	unmatched    []string
+}
+
+// NewRecorder returns a new Recorder for the golden file at path.
+// In Replay mode, the file is loaded and must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == Record {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("backlogtest: invalid golden file %s: %v", path, err)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// Client returns an http.Client which uses the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == Record {
+		return r.record(req, body)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL).String(),
+			Header: redactHeader(req.Header),
+			Body:   redactForm(string(body)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = redactTokens(string(respBody))
+	} else {
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(respBody)
+		interaction.Response.BodyBase64 = true
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := matchKey(req.Method, req.URL)
+	for i, interaction := range r.interactions {
+		if r.used[i] {
+			continue
+		}
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, u) != key {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.httpResponse(req)
+	}
+
+	r.unmatched = append(r.unmatched, key)
+	return nil, &UnmatchedRequestError{Request: key, Unused: r.unusedKeys()}
+}
+
+func (resp RecordedResponse) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(resp.Body)
+	if resp.BodyBase64 {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(resp.Body); err != nil {
+			return nil, err
+		}
+	}
+	header := http.Header{}
+	for k, v := range resp.Header {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// UnmatchedRequestError is returned in Replay mode if no recorded interaction
+// matches the request.
+type UnmatchedRequestError struct {
+	Request string   // e.g. "GET /api/v2/issues/WEB-1"
+	Unused  []string // the recorded requests not replayed yet
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	if len(e.Unused) == 0 {
+		return fmt.Sprintf("backlogtest: unmatched request %s (no recorded requests left)", e.Request)
+	}
+	return fmt.Sprintf("backlogtest: unmatched request %s; not replayed yet:\n\t%s", e.Request, strings.Join(e.Unused, "\n\t"))
+}
+
+// Close saves the golden file in Record mode. In Replay mode, it returns an
+// error if any request was unmatched or any recorded interaction was not
+// replayed, which usually means the golden file is out of date.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == Record {
+		interactions := r.interactions
+		if interactions == nil {
+			interactions = []*Interaction{}
+		}
+		data, err := json.MarshalIndent(interactions, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+	}
+
+	unused := r.unusedKeys()
+	if len(r.unmatched) == 0 && len(unused) == 0 {
+		return nil
+	}
+	var s []string
+	for _, key := range r.unmatched {
+		s = append(s, "unmatched "+key)
+	}
+	for _, key := range unused {
+		s = append(s, "not replayed "+key)
+	}
+	return fmt.Errorf("backlogtest: %s is out of date:\n\t%s", r.path, strings.Join(s, "\n\t"))
+}
+
+func (r *Recorder) unusedKeys() []string {
+	var keys []string
+	for i, interaction := range r.interactions {
+		if r.used[i] {
+			continue
+		}
+		if u, err := url.Parse(interaction.Request.URL); err == nil {
+			keys = append(keys, matchKey(interaction.Request.Method, u))
+		}
+	}
+	return keys
+}
+
+// matchKey returns the method, the path and the sorted query without the
+// apiKey parameter and the OAuth parameters, which differ between runs.
+func matchKey(method string, u *url.URL) string {
+	q := u.Query()
+	for _, k := range redactedParams {
+		q.Del(k)
+	}
+	key := method + " " + u.Path
+	if len(q) > 0 {
+		key += "?" + q.Encode()
+	}
+	return key
+}
+
+// RecordEnabled reports whether the BACKLOGTEST_RECORD environment variable is
+// set, which is a convention to switch tests to Record mode.
+func RecordEnabled() bool {
+	return len(os.Getenv("BACKLOGTEST_RECORD")) > 0
+}
+
+const redacted = "REDACTED"
+
+var (
+	redactedParams = []string{"apiKey", "access_token", "refresh_token", "client_secret", "code"}
+	tokenPattern   = regexp.MustCompile(`"(access_token|refresh_token)"\s*:\s*"[^"]*"`)
+)
+
+// redactHeader redacts the headers which carry credentials.
+func redactHeader(h http.Header) http.Header {
+	header := http.Header{}
+	for k, v := range h {
+		if backlog.IsSensitiveHeader(k) {
+			v = []string{redacted}
+		}
+		header[k] = append([]string(nil), v...)
+	}
+	return header
+}
+
+// redactURL redacts the apiKey parameter and the OAuth parameters in the query.
+func redactURL(u *url.URL) *url.URL {
+	redactedURL := backlog.SanitizeURL(u)
+	q := redactedURL.Query()
+	changed := false
+	for _, k := range redactedParams {
+		if _, ok := q[k]; ok {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		redactedURL.RawQuery = q.Encode()
+	}
+	return redactedURL
+}
+
+// redactForm redacts the OAuth parameters in a url-encoded request body.
+func redactForm(body string) string {
+	form, err := url.ParseQuery(body)
+	if err != nil || len(body) == 0 {
+		return body
+	}
+	changed := false
+	for _, k := range redactedParams {
+		if _, ok := form[k]; ok {
+			form.Set(k, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return body
+	}
+	return form.Encode()
+}
+
+// redactTokens redacts the tokens in the response of the OAuth token endpoint.
+func redactTokens(body string) string {
+	return tokenPattern.ReplaceAllString(body, `"$1":"`+redacted+`"`)
+}
@@ -0,0 +1,148 @@
+package backlogtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+func TestRecorder(t *testing.T) {
+	server, _ := setup(t)
+	server.AddIssue("WEB", backlog.Issue{Summary: "Login fails"})
+	path := filepath.Join(t.TempDir(), "golden.json")
+
+	// Record the interactions with the fake server.
+	recorder, err := NewRecorder(path, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backlog.NewClient(&http.Client{Transport: &authTransport{recorder}}, "backlogtest", APIKey)
+	client.BaseURL, _ = url.Parse(server.URL() + "/api/v2/")
+	if _, _, err := client.Issues.Get("WEB-1"); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, _, err := client.Issues.Get("WEB-2"); err == nil {
+		t.Fatalf("Get returned no error for unknown issue")
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	for _, secret := range []string{APIKey, "secret-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("golden file contains %q:\n%s", secret, data)
+		}
+	}
+
+	// Replay them with a client pointing to nowhere.
+	recorder, err = NewRecorder(path, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = backlog.NewClient(recorder.Client(), "backlogtest", "another-key")
+	client.BaseURL, _ = url.Parse("http://backlog.invalid/api/v2/")
+
+	issue, _, err := client.Issues.Get("WEB-1")
+	if err != nil || issue.Summary != "Login fails" {
+		t.Errorf("Get returned %+v, %v", issue, err)
+	}
+	if _, _, err := client.Issues.Get("WEB-2"); err == nil {
+		t.Errorf("Get returned no error for the recorded 404")
+	}
+	if _, _, err := client.Issues.Get("WEB-1"); err == nil || !strings.Contains(err.Error(), "unmatched request GET /api/v2/issues/WEB-1") {
+		t.Errorf("Get returned %v for a request not recorded, want unmatched request error", err)
+	}
+	if err := recorder.Close(); err == nil {
+		t.Errorf("Close returned no error after an unmatched request")
+	}
+}
+
+func TestMatchKey(t *testing.T) {
+	a, _ := url.Parse("https://a.backlog.jp/api/v2/issues?projectId[]=1&apiKey=x&count=10")
+	b, _ := url.Parse("http://localhost/api/v2/issues?count=10&projectId[]=1&apiKey=REDACTED&access_token=REDACTED")
+	if matchKey("GET", a) != matchKey("GET", b) {
+		t.Errorf("matchKey(%v) = %q, matchKey(%v) = %q, want equal", a, matchKey("GET", a), b, matchKey("GET", b))
+	}
+}
+
+func TestRecorder_accessToken(t *testing.T) {
+	server, _ := setup(t)
+	server.AddIssue("WEB", backlog.Issue{Summary: "Login fails"})
+	path := filepath.Join(t.TempDir(), "golden.json")
+
+	recorder, err := NewRecorder(path, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := backlog.NewClient(&http.Client{Transport: &queryTokenTransport{recorder, "secret-token"}}, "backlogtest", APIKey)
+	client.BaseURL, _ = url.Parse(server.URL() + "/api/v2/")
+	if _, _, err := client.Issues.Get("WEB-1"); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	data, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(data), "secret-token") || !strings.Contains(string(data), "access_token=REDACTED") {
+		t.Errorf("golden file does not redact access_token:\n%s", data)
+	}
+
+	// Replay with another token.
+	recorder, err = NewRecorder(path, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = backlog.NewClient(&http.Client{Transport: &queryTokenTransport{recorder, "another-token"}}, "backlogtest", "another-key")
+	client.BaseURL, _ = url.Parse("http://backlog.invalid/api/v2/")
+	if _, _, err := client.Issues.Get("WEB-1"); err != nil {
+		t.Errorf("Get returned error: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+// authTransport adds an OAuth token which must not be recorded.
+type authTransport struct {
+	next http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer secret-token")
+	return t.next.RoundTrip(req)
+}
+
+// queryTokenTransport adds an OAuth token to the query.
+type queryTokenTransport struct {
+	next  http.RoundTripper
+	token string
+}
+
+func (t *queryTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	q.Set("access_token", t.token)
+	req.URL.RawQuery = q.Encode()
+	return t.next.RoundTrip(req)
+}
+
+func TestRedact(t *testing.T) {
+	u, _ := url.Parse("https://a.backlog.jp/api/v2/issues?apiKey=x&access_token=abc&code=def&count=10")
+	if got, want := redactURL(u).String(), "https://a.backlog.jp/api/v2/issues?access_token=REDACTED&apiKey=REDACTED&code=REDACTED&count=10"; got != want {
+		t.Errorf("redactURL returned %q, want %q", got, want)
+	}
+	if got := u.Query().Get("access_token"); got != "abc" {
+		t.Errorf("redactURL modified the URL: access_token=%q", got)
+	}
+	if got, want := redactForm("grant_type=refresh_token&refresh_token=abc&client_secret=xyz"), "client_secret=REDACTED&grant_type=refresh_token&refresh_token=REDACTED"; got != want {
+		t.Errorf("redactForm returned %q, want %q", got, want)
+	}
+	if got, want := redactTokens(`{"access_token": "abc","token_type":"Bearer","refresh_token":"def"}`), `{"access_token":"REDACTED","token_type":"Bearer","refresh_token":"REDACTED"}`; got != want {
+		t.Errorf("redactTokens returned %q, want %q", got, want)
+	}
+}
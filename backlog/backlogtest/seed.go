@@ -0,0 +1,230 @@
+package backlogtest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// The seeding methods below panic if the project, the issue or the user does
+// not exist, since it is a mistake in the test.
+
+// SetSpace sets the space returned by GET /space.
+func (s *Server) SetSpace(space backlog.Space) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.space = space
+}
+
+// AddUser adds a user to the space. The first user added is the owner of the
+// API key, which is returned by GET /users/myself.
+func (s *Server) AddUser(userID, name string) backlog.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := &backlog.User{ID: s.newID(), UserID: userID, Name: name, MailAddress: userID + "@example.com"}
+	s.users = append(s.users, user)
+	if s.myself == nil {
+		s.myself = user
+	}
+	return *user
+}
+
+// SetMyself sets the owner of the API key.
+func (s *Server) SetMyself(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.myself = s.mustUser(userID)
+}
+
+// AddProject adds a project.
+func (s *Server) AddProject(projectKey, name string) backlog.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.findProject(projectKey) != nil {
+		panic(fmt.Sprintf("backlogtest: duplicate project %s", projectKey))
+	}
+	p := &project{Project: backlog.Project{ID: s.newID(), ProjectKey: projectKey, Name: name}}
+	s.projects = append(s.projects, p)
+	return p.Project
+}
+
+// AddProjectUser adds the user to the members of the project.
+func (s *Server) AddProjectUser(projectKey string, userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.mustProject(projectKey)
+	s.mustUser(userID)
+	p.userIDs = append(p.userIDs, userID)
+}
+
+// AddIssueType adds an issue type to the project.
+func (s *Server) AddIssueType(projectKey, name, color string) backlog.IssueType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addIssueType(s.mustProject(projectKey), name, color)
+}
+
+// AddCategory adds a category to the project.
+func (s *Server) AddCategory(projectKey, name string) backlog.Category {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addCategory(s.mustProject(projectKey), name)
+}
+
+// AddVersion adds a version (milestone) to the project.
+func (s *Server) AddVersion(projectKey, name string) backlog.Version {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.mustProject(projectKey)
+	v := &backlog.Version{ID: s.newID(), ProjectKey: projectKey, Name: name}
+	p.versions = append(p.versions, v)
+	return *v
+}
+
+// AddIssue adds an issue to the project. The ID, the issue key, the project
+// ID and the created and updated times are set by the server, and the status
+// defaults to "Open" and the priority to "Normal".
+func (s *Server) AddIssue(projectKey string, issue backlog.Issue) backlog.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.mustProject(projectKey)
+	if issue.Status.ID == 0 {
+		issue.Status.ID, issue.Status.Name = 1, "Open"
+	}
+	if issue.Priority.ID == 0 {
+		issue.Priority = backlog.Priority{ID: 3, Name: "Normal"}
+	}
+	if issue.IssueType.ID == 0 && len(p.issueTypes) > 0 {
+		issue.IssueType = *p.issueTypes[0]
+	}
+	if s.myself != nil && issue.CreatedUser.ID == 0 {
+		issue.CreatedUser.ID, issue.CreatedUser.UserID, issue.CreatedUser.Name = s.myself.ID, s.myself.UserID, s.myself.Name
+	}
+	return *s.addIssue(p, &issue)
+}
+
+// AddComment adds a comment to the issue.
+func (s *Server) AddComment(issueIDOrKey, content string) backlog.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issue := s.findIssue(issueIDOrKey)
+	if issue == nil {
+		panic(fmt.Sprintf("backlogtest: unknown issue %s", issueIDOrKey))
+	}
+	return *s.addComment(issue, content, nil)
+}
+
+// Issue returns the current state of the issue, or false if it does not exist.
+func (s *Server) Issue(issueIDOrKey string) (backlog.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issue := s.findIssue(issueIDOrKey)
+	if issue == nil {
+		return backlog.Issue{}, false
+	}
+	return *issue, true
+}
+
+// Comments returns the comments of the issue in the order they were added.
+func (s *Server) Comments(issueIDOrKey string) []backlog.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issue := s.findIssue(issueIDOrKey)
+	if issue == nil {
+		return nil
+	}
+	comments := make([]backlog.IssueComment, 0, len(s.comments[issue.ID]))
+	for _, c := range s.comments[issue.ID] {
+		comments = append(comments, *c)
+	}
+	return comments
+}
+
+func (s *Server) addIssueType(p *project, name, color string) *backlog.IssueType {
+	t := &backlog.IssueType{ID: s.newID(), ProjectID: p.ID, Name: name, Color: color, DisplayOrder: len(p.issueTypes)}
+	p.issueTypes = append(p.issueTypes, t)
+	return t
+}
+
+func (s *Server) addCategory(p *project, name string) *backlog.Category {
+	c := &backlog.Category{ID: s.newID(), ProjectKey: p.ProjectKey, Name: name}
+	p.categories = append(p.categories, c)
+	return c
+}
+
+func (s *Server) addIssue(p *project, issue *backlog.Issue) *backlog.Issue {
+	p.lastKeyID++
+	issue.ID = s.newID()
+	issue.ProjectID = p.ID
+	issue.KeyID = p.lastKeyID
+	issue.IssueKey = p.ProjectKey + "-" + strconv.Itoa(p.lastKeyID)
+	if issue.Created.IsZero() {
+		issue.Created = s.now()
+	}
+	if issue.Updated.IsZero() {
+		issue.Updated = issue.Created
+	}
+	s.issues = append(s.issues, issue)
+	return issue
+}
+
+func (s *Server) addComment(issue *backlog.Issue, content string, changes []backlog.ChangeLog) *backlog.IssueComment {
+	c := &backlog.IssueComment{ID: s.newID(), Content: content, ChangeLogs: changes}
+	c.Created = s.now()
+	c.Updated = c.Created
+	if s.myself != nil {
+		c.CreatedUser.ID, c.CreatedUser.UserID, c.CreatedUser.Name = s.myself.ID, s.myself.UserID, s.myself.Name
+	}
+	s.comments[issue.ID] = append(s.comments[issue.ID], c)
+	return c
+}
+
+func (s *Server) mustProject(projectKey string) *project {
+	p := s.findProject(projectKey)
+	if p == nil {
+		panic(fmt.Sprintf("backlogtest: unknown project %s", projectKey))
+	}
+	return p
+}
+
+func (s *Server) mustUser(id int) *backlog.User {
+	u := s.findUser(id)
+	if u == nil {
+		panic(fmt.Sprintf("backlogtest: unknown user %d", id))
+	}
+	return u
+}
+
+// findProject finds a project by the project key or the project ID.
+func (s *Server) findProject(idOrKey string) *project {
+	for _, p := range s.projects {
+		if p.ProjectKey == idOrKey || strconv.Itoa(p.ID) == idOrKey {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) findProjectByID(id int) *project {
+	return s.findProject(strconv.Itoa(id))
+}
+
+// findIssue finds an issue by the issue key or the issue ID.
+func (s *Server) findIssue(idOrKey string) *backlog.Issue {
+	for _, issue := range s.issues {
+		if issue.IssueKey == idOrKey || strconv.Itoa(issue.ID) == idOrKey {
+			return issue
+		}
+	}
+	return nil
+}
+
+func (s *Server) findUser(id int) *backlog.User {
+	for _, u := range s.users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Package backlogtest provides an in-memory fake of the Backlog API for tests.
+//
+//	server := backlogtest.NewServer()
+//	defer server.Close()
+//	server.AddProject("WEB", "Web")
+//	client := server.Client()
+package backlogtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// APIKey is the API key accepted by Server.
+const APIKey = "backlogtest-api-key"
+
+// Backlog API error codes
+const (
+	InternalError              = 1
+	LicenceError               = 2
+	LicenceExpiredError        = 3
+	AccessDeniedError          = 4
+	UnauthorizedOperationError = 5
+	NoResourceError            = 6
+	InvalidRequestError        = 7
+	SpaceOverCapacityError     = 8
+	ResourceOverflowError      = 9
+	TooLargeFileError          = 10
+	AuthenticationError        = 11
+)
+
+// Server is an HTTP server which implements a subset of the Backlog API on
+// in-memory state: space, priorities, statuses, resolutions, users, projects,
+// issue types, categories, versions, issues and comments.
+//
+// Errors are returned in the format of the Backlog API, which the client
+// decodes into *backlog.ErrorResponse.
+type Server struct {
+	// Now returns the current time for created and updated times.
+	Now func() time.Time
+
+	server *httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	space       backlog.Space
+	myself      *backlog.User
+	users       []*backlog.User
+	priorities  []*backlog.Priority
+	statuses    []*backlog.Status
+	resolutions []*backlog.Resolution
+	projects    []*project
+	issues      []*backlog.Issue
+	comments    map[int][]*backlog.IssueComment // by issue ID
+}
+
+type project struct {
+	backlog.Project
+	issueTypes []*backlog.IssueType
+	categories []*backlog.Category
+	versions   []*backlog.Version
+	userIDs    []int
+	lastKeyID  int
+}
+
+// NewServer starts and returns a new Server with the built-in priorities,
+// statuses and resolutions of Backlog. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		Now:      time.Now,
+		nextID:   100,
+		space:    backlog.Space{SpaceKey: "backlogtest", Name: "backlogtest", Lang: "en", Timezone: "UTC", TextFormattingRule: "markdown"},
+		comments: map[int][]*backlog.IssueComment{},
+		priorities: []*backlog.Priority{
+			{ID: 2, Name: "High"}, {ID: 3, Name: "Normal"}, {ID: 4, Name: "Low"},
+		},
+		statuses: []*backlog.Status{
+			{ID: 1, Name: "Open"}, {ID: 2, Name: "In Progress"}, {ID: 3, Name: "Resolved"}, {ID: 4, Name: "Closed"},
+		},
+		resolutions: []*backlog.Resolution{
+			{ID: 0, Name: "Fixed"}, {ID: 1, Name: "Won't Fix"}, {ID: 2, Name: "Invalid"}, {ID: 3, Name: "Duplication"}, {ID: 4, Name: "Cannot Reproduce"},
+		},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the server, e.g. "http://127.0.0.1:8080".
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a new backlog.Client which talks to the server.
+func (s *Server) Client() *backlog.Client {
+	client := backlog.NewClient(s.server.Client(), "backlogtest", APIKey)
+	client.BaseURL, _ = url.Parse(s.server.URL + "/api/v2/")
+	return client
+}
+
+// errorBody is the error response body of the Backlog API.
+type errorBody struct {
+	Errors []backlog.Error `json:"errors"`
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, errorBody{Errors: []backlog.Error{{Message: message, Code: code, MoreInfo: ""}}})
+}
+
+func notFound(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusNotFound, NoResourceError, message)
+}
+
+func badRequest(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, InvalidRequestError, message)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("apiKey") != APIKey {
+		writeError(w, http.StatusUnauthorized, AuthenticationError, "Authentication failure.")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/api/v2/") {
+		notFound(w, "No such API.")
+		return
+	}
+	form, err := parseForm(r)
+	if err != nil {
+		badRequest(w, "Invalid request body.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/")
+	req := &request{method: r.Method, path: path, query: r.URL.Query(), form: form}
+	switch path[0] {
+	case "space", "priorities", "statuses", "resolutions", "users":
+		s.serveSpace(w, req)
+	case "projects":
+		s.serveProjects(w, req)
+	case "issues":
+		s.serveIssues(w, req)
+	default:
+		notFound(w, "No such API.")
+	}
+}
+
+type request struct {
+	method string
+	path   []string
+	query  url.Values
+	form   url.Values
+}
+
+// match reports whether the request has the method and the path, where "*" in
+// pattern matches any segment.
+func (r *request) match(method string, pattern ...string) bool {
+	if r.method != method || len(r.path) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != r.path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseForm parses the url-encoded body, including the body of DELETE
+// requests which http.Request.ParseForm ignores.
+func parseForm(r *http.Request) (url.Values, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return url.Values{}, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(string(body))
+}
+
+func formInt(form url.Values, key string) (int, bool) {
+	i, err := strconv.Atoi(form.Get(key))
+	return i, err == nil
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) now() time.Time {
+	return s.Now().UTC().Truncate(time.Second)
+}
@@ -0,0 +1,237 @@
+package backlogtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+func setup(t *testing.T) (*Server, *backlog.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	alice := server.AddUser("alice", "Alice")
+	server.AddProject("WEB", "Web")
+	server.AddProjectUser("WEB", alice.ID)
+	server.AddIssueType("WEB", "Bug", "#990000")
+	server.AddIssueType("WEB", "Task", "#7ea800")
+	server.AddCategory("WEB", "Frontend")
+	server.AddVersion("WEB", "v1.0")
+	return server, server.Client()
+}
+
+func intp(i int) *int       { return &i }
+func strp(s string) *string { return &s }
+
+func TestServer_issueLifecycle(t *testing.T) {
+	server, client := setup(t)
+
+	r := backlog.NewResolver(client, 0)
+	request, err := backlog.NewIssue("WEB").Summary("Login fails").Type("Bug").Priority("High").
+		Assignee("alice").Category("Frontend").Milestone("v1.0").BuildCreate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue, _, err := client.Issues.Create(request)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if issue.IssueKey != "WEB-1" || issue.IssueType.Name != "Bug" || issue.Priority.Name != "High" ||
+		issue.Assignee.Name != "Alice" || issue.Status.Name != "Open" {
+		t.Errorf("Create returned %+v", issue)
+	}
+	if len(issue.Categories) != 1 || len(issue.Milestones) != 1 {
+		t.Errorf("Create returned categories %v, milestones %v", issue.Categories, issue.Milestones)
+	}
+
+	issue, _, err = client.Issues.Edit("WEB-1", backlog.IssueRequest{StatusID: intp(4), Comment: strp("done")})
+	if err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if issue.Status.Name != "Closed" {
+		t.Errorf("Edit returned status %v, want Closed", issue.Status.Name)
+	}
+
+	comments, _, err := client.Issues.ListComments("WEB-1", "asc")
+	if err != nil {
+		t.Fatalf("ListComments returned error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Content != "done" || len(comments[0].ChangeLogs) != 1 ||
+		comments[0].ChangeLogs[0] != (backlog.ChangeLog{Field: "status", NewValue: "Closed", OriginalValue: "Open"}) {
+		t.Errorf("ListComments returned %+v", comments)
+	}
+
+	if _, err := client.Issues.Delete("WEB-1"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, ok := server.Issue("WEB-1"); ok {
+		t.Errorf("issue WEB-1 exists after Delete")
+	}
+}
+
+func TestServer_search(t *testing.T) {
+	server, client := setup(t)
+	for _, summary := range []string{"a", "b", "c"} {
+		server.AddIssue("WEB", backlog.Issue{Summary: summary})
+	}
+	closed := backlog.Issue{Summary: "d"}
+	closed.Status.ID, closed.Status.Name = 4, "Closed"
+	server.AddIssue("WEB", closed)
+
+	issues, _, err := client.Issues.SearchAll(backlog.IssueSearchRequest{StatusIDs: []int{1}, Order: strp("asc"), Count: intp(2)})
+	if err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	var summaries []string
+	for _, issue := range issues {
+		summaries = append(summaries, issue.Summary)
+	}
+	if len(summaries) != 3 || summaries[0] != "a" || summaries[2] != "c" {
+		t.Errorf("SearchAll returned %v, want [a b c]", summaries)
+	}
+}
+
+func TestServer_searchFilters(t *testing.T) {
+	server, client := setup(t)
+	project, _, _ := client.Projects.Get("WEB")
+	v2 := server.AddVersion("WEB", "v2.0")
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	server.AddIssue("WEB", backlog.Issue{Summary: "b", DueDate: day("2026-10-01")})
+	server.AddIssue("WEB", backlog.Issue{Summary: "a", DueDate: day("2026-10-15"), Versions: []backlog.Version{v2}})
+	server.AddIssue("WEB", backlog.Issue{Summary: "c"})
+
+	if _, _, err := client.Issues.Edit("WEB-3", backlog.IssueRequest{ResolutionID: intp(1)}); err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if issue, _ := server.Issue("WEB-3"); issue.Resolution == nil || issue.Resolution.ID != 1 {
+		t.Errorf("Edit stored resolution %+v, want 1", issue.Resolution)
+	}
+
+	tests := []struct {
+		request backlog.IssueSearchRequest
+		want    string
+	}{
+		{backlog.IssueSearchRequest{VersionIDs: []int{v2.ID}}, "[a]"},
+		{backlog.IssueSearchRequest{ResolutionIDs: []int{1}}, "[c]"},
+		{backlog.IssueSearchRequest{DueDateSince: strp("2026-10-01"), DueDateUntil: strp("2026-10-10")}, "[b]"},
+		{backlog.IssueSearchRequest{DueDateSince: strp("2026-10-01"), Sort: strp("dueDate"), Order: strp("asc")}, "[b a]"},
+		{backlog.IssueSearchRequest{ProjectIDs: []int{project.ID}, Sort: strp("summary"), Order: strp("asc")}, "[a b c]"},
+		{backlog.IssueSearchRequest{Sort: strp("summary")}, "[c b a]"},
+	}
+	for _, tt := range tests {
+		issues, _, err := client.Issues.Search(tt.request)
+		if err != nil {
+			t.Errorf("Search(%+v) returned error: %v", tt.request, err)
+			continue
+		}
+		var summaries []string
+		for _, issue := range issues {
+			summaries = append(summaries, issue.Summary)
+		}
+		if got := fmt.Sprint(summaries); got != tt.want {
+			t.Errorf("Search(%+v) returned %v, want %v", tt.request, got, tt.want)
+		}
+	}
+
+	for _, request := range []backlog.IssueSearchRequest{
+		{Sort: strp("estimatedHours")},
+		{DueDateSince: strp("10/01")},
+	} {
+		_, _, err := client.Issues.Search(request)
+		if e, ok := err.(*backlog.ErrorResponse); !ok || e.Response.StatusCode != http.StatusBadRequest {
+			t.Errorf("Search(%+v) returned %v, want 400", request, err)
+		}
+	}
+
+	req, _ := client.NewRequest("GET", "issues?attachment=true", nil)
+	_, err := client.Do(req, nil)
+	if e, ok := err.(*backlog.ErrorResponse); !ok || e.Response.StatusCode != http.StatusBadRequest {
+		t.Errorf("GET issues?attachment=true returned %v, want 400", err)
+	}
+}
+
+func TestServer_errors(t *testing.T) {
+	server, client := setup(t)
+	project, _, _ := client.Projects.Get("WEB")
+
+	tests := []struct {
+		name   string
+		call   func() (*backlog.Response, error)
+		status int
+		code   int
+	}{
+		{"no issue", func() (*backlog.Response, error) {
+			_, resp, err := client.Issues.Get("WEB-99")
+			return resp, err
+		}, http.StatusNotFound, NoResourceError},
+		{"no project", func() (*backlog.Response, error) {
+			_, resp, err := client.Projects.Get("NOPE")
+			return resp, err
+		}, http.StatusNotFound, NoResourceError},
+		{"missing summary", func() (*backlog.Response, error) {
+			_, resp, err := client.Issues.Create(backlog.IssueRequest{ProjectID: &project.ID, IssueTypeID: intp(1), PriorityID: intp(3)})
+			return resp, err
+		}, http.StatusBadRequest, InvalidRequestError},
+		{"invalid API key", func() (*backlog.Response, error) {
+			c := backlog.NewClient(nil, "backlogtest", "wrong")
+			c.BaseURL = server.Client().BaseURL
+			_, resp, err := c.Space.Get()
+			return resp, err
+		}, http.StatusUnauthorized, AuthenticationError},
+	}
+	for _, tt := range tests {
+		_, err := tt.call()
+		e, ok := err.(*backlog.ErrorResponse)
+		if !ok {
+			t.Errorf("%s: returned error %v, want *backlog.ErrorResponse", tt.name, err)
+			continue
+		}
+		if e.Response.StatusCode != tt.status || len(e.Errors) != 1 || e.Errors[0].Code != tt.code {
+			t.Errorf("%s: returned %d %+v, want %d with code %d", tt.name, e.Response.StatusCode, e.Errors, tt.status, tt.code)
+		}
+	}
+}
+
+func TestServer_deleteIssueType(t *testing.T) {
+	server, client := setup(t)
+	task := server.AddIssueType("WEB", "Spec", "#000000")
+	bug := server.AddIssue("WEB", backlog.Issue{Summary: "a", IssueType: task})
+
+	types, _, _ := client.Projects.ListIssueTypes("WEB")
+	if _, err := client.Projects.DeleteIssueType("WEB", strconv.Itoa(task.ID), strconv.Itoa(types[0].ID)); err != nil {
+		t.Fatalf("DeleteIssueType returned error: %v", err)
+	}
+	issue, _ := server.Issue(bug.IssueKey)
+	if issue.IssueType.ID != types[0].ID {
+		t.Errorf("issue type is %v after DeleteIssueType, want %v", issue.IssueType.Name, types[0].Name)
+	}
+}
+
+func TestServer_deleteParentIssue(t *testing.T) {
+	server, client := setup(t)
+	parent := server.AddIssue("WEB", backlog.Issue{Summary: "parent"})
+	child := server.AddIssue("WEB", backlog.Issue{Summary: "child", ParentIssueID: &parent.ID})
+
+	if _, err := client.Issues.Delete(parent.IssueKey); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	got, _, err := client.Issues.GetParent(child.IssueKey)
+	if err != nil || got != nil {
+		t.Errorf("GetParent of the child returned %+v, %v, want no parent", got, err)
+	}
+	nodes, _, err := client.Issues.Tree("WEB")
+	if err != nil {
+		t.Fatalf("Tree returned error: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Issue.ID != child.ID {
+		t.Errorf("Tree returned %d roots, want the child only", len(nodes))
+	}
+}
@@ -0,0 +1,184 @@
+package backlog
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultBulkWorkers is the number of concurrent requests of the bulk methods by default.
+const defaultBulkWorkers = 4
+
+// maxBulkRetries is the number of retries of a request rejected by the rate limit.
+const maxBulkRetries = 3
+
+// BulkOptions configures the bulk methods such as IssuesService.GetMany.
+type BulkOptions struct {
+	// Workers is the maximum number of concurrent requests. The default is 4.
+	Workers int
+
+	// OnRateLimitWait is called before the workers wait for the rate limit
+	// to be reset, e.g. to log or measure the waits.
+	OnRateLimitWait func(d time.Duration)
+}
+
+// IssueResult is the result of an issue in IssuesService.GetMany.
+type IssueResult struct {
+	Key   string
+	Issue *Issue
+	Err   error
+}
+
+// GetMany gets the issues of the keys concurrently. The results are in the
+// order of keys, and a failed issue does not stop the others.
+func (s *IssuesService) GetMany(keys []string, opt *BulkOptions) []IssueResult {
+	results := make([]IssueResult, len(keys))
+	runBulk(len(keys), opt, func(i int) (*Response, error) {
+		issue, resp, err := s.Get(keys[i])
+		results[i] = IssueResult{Key: keys[i], Issue: issue, Err: err}
+		return resp, err
+	})
+	return results
+}
+
+// ProjectMetadata is the metadata of a project fetched by ProjectsService.GetMetadata.
+type ProjectMetadata struct {
+	ProjectKey string
+	IssueTypes []*IssueType
+	Categories []*Category
+	Versions   []*Version
+	Users      []*User
+
+	// Errs are the errors of the lists which failed, whose fields are nil.
+	Errs []error
+}
+
+// GetMetadata gets the issue types, categories, versions and users of the
+// projects concurrently. The results are in the order of projectKeys, and a
+// failed list does not stop the others.
+func (s *ProjectsService) GetMetadata(projectKeys []string, opt *BulkOptions) []ProjectMetadata {
+	const lists = 4
+	results := make([]ProjectMetadata, len(projectKeys))
+	errs := make([]error, len(projectKeys)*lists)
+	for i, key := range projectKeys {
+		results[i].ProjectKey = key
+	}
+
+	runBulk(len(projectKeys)*lists, opt, func(i int) (resp *Response, err error) {
+		key, m := projectKeys[i/lists], &results[i/lists]
+		switch i % lists {
+		case 0:
+			m.IssueTypes, resp, err = s.ListIssueTypes(key)
+		case 1:
+			m.Categories, resp, err = s.ListCategories(key)
+		case 2:
+			m.Versions, resp, err = s.ListVersions(key)
+		case 3:
+			m.Users, resp, err = s.ListUsers(key)
+		}
+		errs[i] = err
+		return resp, err
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			results[i/lists].Errs = append(results[i/lists].Errs, err)
+		}
+	}
+	return results
+}
+
+// runBulk calls fn for 0 to n-1 concurrently with the workers of opt.
+// The workers share the rate limit reported by the responses: they wait for
+// the reset when no request remains, and retry the requests rejected by the
+// rate limit.
+func runBulk(n int, opt *BulkOptions, fn func(i int) (*Response, error)) {
+	workers := defaultBulkWorkers
+	gate := &rateGate{now: time.Now, sleep: time.Sleep}
+	if opt != nil {
+		if opt.Workers > 0 {
+			workers = opt.Workers
+		}
+		gate.onWait = opt.OnRateLimitWait
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				for retry := 0; ; retry++ {
+					gate.wait()
+					resp, err := fn(i)
+					limited := gate.observe(resp, err)
+					if !limited || retry == maxBulkRetries {
+						break
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// rateGate blocks the requests while the rate limit has no remaining requests.
+type rateGate struct {
+	now    func() time.Time
+	sleep  func(time.Duration)
+	onWait func(time.Duration)
+
+	mu      sync.Mutex
+	blocked time.Time // the requests wait until this time
+}
+
+// wait waits until the rate limit is reset if it is blocked.
+func (g *rateGate) wait() {
+	g.mu.Lock()
+	d := g.blocked.Sub(g.now())
+	g.mu.Unlock()
+	if d <= 0 {
+		return
+	}
+	if g.onWait != nil {
+		g.onWait(d)
+	}
+	g.sleep(d)
+}
+
+// observe records the rate limit of a response, and reports whether the
+// request was rejected by the rate limit.
+func (g *rateGate) observe(resp *Response, err error) bool {
+	if resp == nil {
+		return false
+	}
+	limited := resp.StatusCode == http.StatusTooManyRequests
+	rate := resp.RateLimit
+	if rate == nil {
+		if limited {
+			// Without the headers, back off for a while.
+			g.block(g.now().Add(time.Minute))
+		}
+		return limited
+	}
+	if limited || rate.Remaining <= 0 {
+		g.block(rate.ResetTime())
+	}
+	return limited
+}
+
+func (g *rateGate) block(until time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until.After(g.blocked) {
+		g.blocked = until
+	}
+}
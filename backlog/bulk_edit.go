@@ -0,0 +1,246 @@
+package backlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BulkEditOptions configures IssuesService.BulkEdit.
+type BulkEditOptions struct {
+	BulkOptions
+
+	// DryRun lists the issues which would be edited without editing them.
+	DryRun bool
+
+	// AuditComment is added to every edited issue, e.g. "Moved to v2.1 by the release script".
+	AuditComment string
+
+	// ProgressFile records the edited issues. If it exists, the issues in it
+	// are skipped, so an interrupted BulkEdit can be resumed by running it
+	// again with the same file. Remove the file to start over.
+	// The file is for the search and the change it was created with, and
+	// BulkEdit with another search or change fails with the file.
+	// AuditComment is not a part of the change, so it can be fixed on resume.
+	ProgressFile string
+}
+
+// BulkEditResult is the result of an issue in BulkEdit.
+type BulkEditResult struct {
+	Issue   *Issue // the issue before the edit
+	Updated *Issue // nil if failed
+	Err     error
+}
+
+// BulkEditReport is the report of BulkEdit.
+type BulkEditReport struct {
+	DryRun    bool
+	Matched   int              // issues matching the search
+	Planned   []*Issue         // issues to be edited in dry-run mode
+	Skipped   []*Issue         // issues already edited according to ProgressFile
+	Succeeded []BulkEditResult // in the order of the search
+	Failed    []BulkEditResult // in the order of the search
+
+	// Unrecorded are the issues edited, but not recorded in ProgressFile.
+	// They are edited again, with the audit comment, if BulkEdit is resumed.
+	Unrecorded []BulkEditResult
+}
+
+func (r *BulkEditReport) String() string {
+	var b strings.Builder
+	if r.DryRun {
+		fmt.Fprintf(&b, "dry run: %d matched, %d to edit, %d skipped\n", r.Matched, len(r.Planned), len(r.Skipped))
+		for _, issue := range r.Planned {
+			fmt.Fprintf(&b, "  would edit %s %s\n", issue.IssueKey, issue.Summary)
+		}
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d matched, %d succeeded, %d failed, %d skipped\n", r.Matched, len(r.Succeeded), len(r.Failed), len(r.Skipped))
+	for _, result := range r.Failed {
+		fmt.Fprintf(&b, "  failed %s: %v\n", result.Issue.IssueKey, strings.TrimSpace(result.Err.Error()))
+	}
+	for _, result := range r.Unrecorded {
+		fmt.Fprintf(&b, "  edited %s, but progress not saved: %v\n", result.Issue.IssueKey, result.Err)
+	}
+	return b.String()
+}
+
+// BulkEdit applies change to all the issues matching search with bounded
+// concurrency. The matching issues are collected before editing, so that the
+// edits do not shift the pages of the search.
+//
+// A failed issue does not stop the others. It returns the report and, if any
+// issue failed or was not recorded in ProgressFile, an error summarizing them.
+func (s *IssuesService) BulkEdit(search IssueSearchRequest, change IssueRequest, opt *BulkEditOptions) (*BulkEditReport, error) {
+	if opt == nil {
+		opt = &BulkEditOptions{}
+	}
+	fingerprint := bulkEditFingerprint(search, change)
+	if len(opt.AuditComment) > 0 {
+		comment := opt.AuditComment
+		if change.Comment != nil && len(*change.Comment) > 0 {
+			comment = *change.Comment + "\n\n" + comment
+		}
+		change.Comment = &comment
+	}
+	if len(change.makeValues()) == 0 {
+		return nil, fmt.Errorf("bulk edit: nothing to change")
+	}
+
+	progress, err := loadBulkEditProgress(opt.ProgressFile, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, _, err := s.SearchAll(search)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BulkEditReport{DryRun: opt.DryRun, Matched: len(issues)}
+	var todo []*Issue
+	for _, issue := range issues {
+		if progress.done[issue.ID] {
+			report.Skipped = append(report.Skipped, issue)
+		} else {
+			todo = append(todo, issue)
+		}
+	}
+	if opt.DryRun {
+		report.Planned = todo
+		return report, nil
+	}
+
+	results := make([]BulkEditResult, len(todo))
+	unrecorded := make([]bool, len(todo))
+	runBulk(len(todo), &opt.BulkOptions, func(i int) (*Response, error) {
+		updated, resp, err := s.Edit(todo[i].IssueKey, change)
+		results[i] = BulkEditResult{Issue: todo[i], Updated: updated, Err: err}
+		if err == nil {
+			if err := progress.add(todo[i].ID); err != nil {
+				results[i].Err = err
+				unrecorded[i] = true
+			}
+		}
+		return resp, err
+	})
+	if err := progress.close(); err != nil {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = err
+				unrecorded[i] = true
+			}
+		}
+	}
+
+	for i, result := range results {
+		switch {
+		case unrecorded[i]:
+			report.Unrecorded = append(report.Unrecorded, result)
+		case result.Err != nil:
+			report.Failed = append(report.Failed, result)
+		default:
+			report.Succeeded = append(report.Succeeded, result)
+		}
+	}
+	if len(report.Unrecorded) > 0 {
+		return report, fmt.Errorf("bulk edit: %d of %d issues failed, %d edited but not recorded in %s",
+			len(report.Failed), len(todo), len(report.Unrecorded), opt.ProgressFile)
+	}
+	if len(report.Failed) > 0 {
+		return report, fmt.Errorf("bulk edit: %d of %d issues failed", len(report.Failed), len(todo))
+	}
+	return report, nil
+}
+
+// bulkEditFingerprint identifies the bulk edit of a progress file by the
+// search without the page size and the change without the audit comment.
+func bulkEditFingerprint(search IssueSearchRequest, change IssueRequest) string {
+	search.Count = nil
+	q, _ := addOptions("issues", search)
+	sum := sha256.Sum256([]byte(q + "\n" + change.makeValues().Encode()))
+	return hex.EncodeToString(sum[:])
+}
+
+// bulkEditProgress is the set of the edited issue IDs, which are appended to
+// the progress file as they are edited.
+//
+// The first line of the file is the bulkEditFingerprint, and each following
+// line is the ID of an edited issue.
+type bulkEditProgress struct {
+	path        string
+	fingerprint string
+
+	mu   sync.Mutex
+	done map[int]bool
+	file *os.File // opened by the first add
+}
+
+func loadBulkEditProgress(path, fingerprint string) (*bulkEditProgress, error) {
+	p := &bulkEditProgress{path: path, fingerprint: fingerprint, done: map[int]bool{}}
+	if len(path) == 0 {
+		return p, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 2 || lines[0] != fingerprint {
+		return nil, fmt.Errorf("bulk edit: progress file %s is for another search or change; remove it to start over", path)
+	}
+	// The last line is empty, or was cut off while being written, in which
+	// case the issue is edited again.
+	for _, line := range lines[1 : len(lines)-1] {
+		id, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("bulk edit: invalid progress file %s: %v", path, err)
+		}
+		p.done[id] = true
+	}
+	return p, nil
+}
+
+// add records that the issue is edited, and appends it to the file.
+func (p *bulkEditProgress) add(issueID int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done[issueID] = true
+	if len(p.path) == 0 {
+		return nil
+	}
+
+	if p.file == nil {
+		f, err := os.OpenFile(p.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		info, err := f.Stat()
+		if err == nil && info.Size() == 0 {
+			_, err = fmt.Fprintln(f, p.fingerprint)
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+		p.file = f
+	}
+	_, err := fmt.Fprintln(p.file, issueID)
+	return err
+}
+
+// close closes the file.
+func (p *bulkEditProgress) close() error {
+	if p.file == nil {
+		return nil
+	}
+	return p.file.Close()
+}
@@ -0,0 +1,198 @@
+package backlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestIssuesService_BulkEdit(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	failing := map[string]bool{"WEB-2": true}
+	edited := map[string]string{}
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("milestoneId[]"); got != "1" {
+			t.Errorf("search milestoneId[] = %q, want 1", got)
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"issueKey":"WEB-1"},{"id":2,"issueKey":"WEB-2"},{"id":3,"issueKey":"WEB-3"}]`)
+	})
+	mux.HandleFunc("/api/v2/issues/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		key := strings.TrimPrefix(r.URL.Path, "/api/v2/issues/")
+		mu.Lock()
+		defer mu.Unlock()
+		if failing[key] {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errors":[{"message":"Invalid milestone.","code":7,"moreInfo":""}]}`)
+			return
+		}
+		r.ParseForm()
+		edited[key] = r.PostForm.Get("milestoneId[]") + " " + r.PostForm.Get("comment")
+		fmt.Fprintf(w, `{"issueKey":%q}`, key)
+	})
+
+	search := IssueSearchRequest{MilestoneIDs: []int{1}}
+	milestone := 2
+	change := IssueRequest{MilestoneID: &milestone}
+	opt := &BulkEditOptions{DryRun: true, AuditComment: "moved by script", ProgressFile: filepath.Join(t.TempDir(), "progress")}
+
+	report, err := client.Issues.BulkEdit(search, change, opt)
+	if err != nil {
+		t.Fatalf("BulkEdit returned error in dry run: %v", err)
+	}
+	if len(report.Planned) != 3 || len(edited) != 0 {
+		t.Errorf("dry run planned %d issues and edited %v, want 3 and none", len(report.Planned), edited)
+	}
+
+	opt.DryRun = false
+	report, err = client.Issues.BulkEdit(search, change, opt)
+	if err == nil {
+		t.Errorf("BulkEdit returned no error with a failed issue")
+	}
+	if report.Matched != 3 || len(report.Succeeded) != 2 || len(report.Failed) != 1 || report.Failed[0].Issue.IssueKey != "WEB-2" {
+		t.Errorf("BulkEdit returned %v", report)
+	}
+	if edited["WEB-1"] != "2 moved by script" {
+		t.Errorf("WEB-1 was edited with %q, want milestone 2 and the audit comment", edited["WEB-1"])
+	}
+
+	// Resume after fixing the failure, with the audit comment fixed as well.
+	failing["WEB-2"] = false
+	edited = map[string]string{}
+	opt.AuditComment = "moved by release script"
+	report, err = client.Issues.BulkEdit(search, change, opt)
+	if err != nil {
+		t.Fatalf("BulkEdit returned error on resume: %v", err)
+	}
+	if len(report.Skipped) != 2 || len(report.Succeeded) != 1 || len(edited) != 1 || edited["WEB-2"] != "2 moved by release script" {
+		t.Errorf("resumed BulkEdit returned %v and edited %v, want WEB-2 only", report, edited)
+	}
+
+	// The progress file is not reused for another change.
+	other := 3
+	edited = map[string]string{}
+	if _, err := client.Issues.BulkEdit(search, IssueRequest{MilestoneID: &other}, opt); err == nil {
+		t.Errorf("BulkEdit returned no error with the progress file of another change")
+	}
+	if len(edited) != 0 {
+		t.Errorf("BulkEdit with the progress file of another change edited %v", edited)
+	}
+}
+
+func TestIssuesService_BulkEdit_unrecorded(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"issueKey":"WEB-1"}]`)
+	})
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"issueKey":"WEB-1"}`)
+	})
+
+	// The directory of the progress file does not exist, so saving it fails.
+	milestone := 2
+	opt := &BulkEditOptions{ProgressFile: filepath.Join(t.TempDir(), "missing", "progress")}
+	report, err := client.Issues.BulkEdit(IssueSearchRequest{}, IssueRequest{MilestoneID: &milestone}, opt)
+	if err == nil {
+		t.Errorf("BulkEdit returned no error when the progress was not saved")
+	}
+	if len(report.Unrecorded) != 1 || len(report.Failed) != 0 || report.Unrecorded[0].Updated == nil {
+		t.Errorf("BulkEdit returned %v, want WEB-1 edited but unrecorded", report)
+	}
+}
+
+func TestIssuesService_BulkEdit_cutOffProgress(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"issueKey":"WEB-1"},{"id":12,"issueKey":"WEB-12"}]`)
+	})
+	var edited []string
+	mux.HandleFunc("/api/v2/issues/", func(w http.ResponseWriter, r *http.Request) {
+		edited = append(edited, strings.TrimPrefix(r.URL.Path, "/api/v2/issues/"))
+		fmt.Fprint(w, `{}`)
+	})
+
+	// The progress file was cut off while appending issue 12.
+	milestone := 2
+	change := IssueRequest{MilestoneID: &milestone}
+	path := filepath.Join(t.TempDir(), "progress")
+	data := bulkEditFingerprint(IssueSearchRequest{}, change) + "\n1\n1"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := client.Issues.BulkEdit(IssueSearchRequest{}, change, &BulkEditOptions{ProgressFile: path})
+	if err != nil {
+		t.Fatalf("BulkEdit returned error: %v", err)
+	}
+	if len(report.Skipped) != 1 || len(edited) != 1 || edited[0] != "WEB-12" {
+		t.Errorf("BulkEdit skipped %d and edited %v, want WEB-12 only", len(report.Skipped), edited)
+	}
+}
+
+func TestIssuesService_BulkEdit_manyPages(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	const matches = 300
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		n := matches - offset
+		if n > 100 {
+			n = 100 // Backlog returns at most 100 issues whatever count is.
+		}
+		issues := []string{}
+		for i := offset; i < offset+n; i++ {
+			issues = append(issues, fmt.Sprintf(`{"id":%d,"issueKey":"WEB-%d"}`, i+1, i+1))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(issues, ","))
+	})
+	var mu sync.Mutex
+	edited := 0
+	mux.HandleFunc("/api/v2/issues/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		edited++
+		mu.Unlock()
+		fmt.Fprint(w, `{}`)
+	})
+
+	count, milestone := 150, 2
+	report, err := client.Issues.BulkEdit(IssueSearchRequest{Count: &count}, IssueRequest{MilestoneID: &milestone}, nil)
+	if err != nil {
+		t.Fatalf("BulkEdit returned error: %v", err)
+	}
+	if report.Matched != matches || len(report.Succeeded) != matches || edited != matches {
+		t.Errorf("BulkEdit matched %d and edited %d issues, want %d", report.Matched, edited, matches)
+	}
+}
+
+func TestIssuesService_BulkEdit_nothingToChange(t *testing.T) {
+	client, _, teardown := setup()
+	defer teardown()
+
+	if _, err := client.Issues.BulkEdit(IssueSearchRequest{}, IssueRequest{}, nil); err == nil {
+		t.Errorf("BulkEdit returned no error without changes")
+	}
+}
@@ -0,0 +1,131 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIssuesService_GetMany(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight, limited := 0, 0, false
+	mux.HandleFunc("/api/v2/issues/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		first429 := !limited && strings.HasSuffix(r.URL.Path, "WEB-3")
+		limited = limited || first429
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		key := strings.TrimPrefix(r.URL.Path, "/api/v2/issues/")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+		switch {
+		case first429:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"errors":[{"message":"Rate limit exceeded.","code":9,"moreInfo":""}]}`)
+		case key == "WEB-2":
+			w.Header().Set("X-RateLimit-Remaining", "100")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"message":"No issue.","code":6,"moreInfo":""}]}`)
+		default:
+			w.Header().Set("X-RateLimit-Remaining", "100")
+			fmt.Fprintf(w, `{"issueKey":%q}`, key)
+		}
+	})
+
+	keys := []string{"WEB-1", "WEB-2", "WEB-3", "WEB-4", "WEB-5"}
+	results := client.Issues.GetMany(keys, &BulkOptions{Workers: 2})
+
+	if len(results) != len(keys) {
+		t.Fatalf("GetMany returned %d results, want %d", len(results), len(keys))
+	}
+	for i, r := range results {
+		if r.Key != keys[i] {
+			t.Errorf("results[%d].Key = %q, want %q", i, r.Key, keys[i])
+		}
+		if r.Key == "WEB-2" {
+			if r.Err == nil || r.Issue != nil {
+				t.Errorf("result of WEB-2 is %+v, want an error", r)
+			}
+			continue
+		}
+		if r.Err != nil || r.Issue == nil || r.Issue.IssueKey != keys[i] {
+			t.Errorf("result of %s is %+v", keys[i], r)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("%d requests were in flight, want at most 2", maxInFlight)
+	}
+}
+
+func TestProjectsService_GetMetadata(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	for _, list := range []string{"issueTypes", "categories", "versions", "users"} {
+		list := list
+		mux.HandleFunc("/api/v2/projects/WEB/"+list, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"id":1,"name":"`+list+`"}]`)
+		})
+	}
+	mux.HandleFunc("/api/v2/projects/NOPE/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"No project.","code":6,"moreInfo":""}]}`)
+	})
+
+	results := client.Projects.GetMetadata([]string{"WEB", "NOPE"}, nil)
+	web, nope := results[0], results[1]
+	if web.ProjectKey != "WEB" || len(web.Errs) != 0 || len(web.IssueTypes) != 1 || len(web.Categories) != 1 ||
+		len(web.Versions) != 1 || web.Users[0].Name != "users" {
+		t.Errorf("GetMetadata returned %+v for WEB", web)
+	}
+	if nope.ProjectKey != "NOPE" || len(nope.Errs) != 4 {
+		t.Errorf("GetMetadata returned %+v for NOPE, want 4 errors", nope)
+	}
+}
+
+func TestRateGate(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var slept, waited time.Duration
+	g := &rateGate{
+		now:    func() time.Time { return now },
+		sleep:  func(d time.Duration) { slept += d },
+		onWait: func(d time.Duration) { waited += d },
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", "1030")
+	if g.observe(newResponse(&http.Response{StatusCode: http.StatusOK, Header: header}), nil) {
+		t.Errorf("observe reported a successful response as rate limited")
+	}
+	g.wait()
+	if slept != 30*time.Second || waited != 30*time.Second {
+		t.Errorf("wait slept %v and reported %v, want 30s", slept, waited)
+	}
+
+	if !g.observe(newResponse(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}), nil) {
+		t.Errorf("observe did not report 429 as rate limited")
+	}
+	slept = 0
+	g.wait()
+	if slept != time.Minute {
+		t.Errorf("wait slept %v without the headers, want 1m", slept)
+	}
+}
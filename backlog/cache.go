@@ -0,0 +1,373 @@
+package backlog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses of GET requests for Client.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse) error
+	// DeletePrefix deletes the responses whose keys start with prefix.
+	DeletePrefix(prefix string) error
+}
+
+// CachedResponse is a response stored in Cache.
+type CachedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Expires    time.Time   `json:"expires"` // fresh until Expires, then revalidated with ETag or Last-Modified
+}
+
+// CacheTTL caches the responses of the endpoints matching Pattern for TTL
+// when Backlog does not tell how long they are fresh.
+// Pattern is matched against the path relative to BaseURL with path.Match,
+// e.g. "projects/*/issueTypes".
+type CacheTTL struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// MetadataCacheTTLs returns the CacheTTLs of the metadata which rarely
+// changes: priorities, statuses, resolutions and the issue types, categories,
+// versions and users of projects.
+func MetadataCacheTTLs(ttl time.Duration) []CacheTTL {
+	var ttls []CacheTTL
+	for _, pattern := range []string{
+		"priorities", "statuses", "resolutions",
+		"projects/*/issueTypes", "projects/*/categories", "projects/*/versions", "projects/*/users",
+	} {
+		ttls = append(ttls, CacheTTL{Pattern: pattern, TTL: ttl})
+	}
+	return ttls
+}
+
+// clientCache is the cache configuration of Client.
+type clientCache struct {
+	cache  Cache
+	ttls   []CacheTTL
+	prefix string // separates the keys of API keys
+	now    func() time.Time
+}
+
+// SetCache makes the client cache the responses of GET requests in cache.
+//
+// Responses with ETag or Last-Modified are revalidated with conditional
+// requests, and are not requested again while Cache-Control max-age allows.
+// Responses of the endpoints in ttls are also cached for the TTL.
+//
+// Successful requests other than GET drop the cached responses of the same
+// path and of the lists containing it, e.g. Issues.Edit("WEB-1", ...) drops
+// the responses of "issues/WEB-1", the searches of "issues" and
+// "issues/count", but not those of the other issues. Use InvalidateCache for
+// other dependencies, such as the same issue requested by its ID.
+// Passing nil disables the cache.
+func (c *Client) SetCache(cache Cache, ttls ...CacheTTL) {
+	if cache == nil {
+		c.cache = nil
+		return
+	}
+	sum := sha256.Sum256([]byte(c.apiKey))
+	c.cache = &clientCache{
+		cache:  cache,
+		ttls:   ttls,
+		prefix: hex.EncodeToString(sum[:8]) + " ",
+		now:    time.Now,
+	}
+}
+
+// InvalidateCache drops the cached responses of the paths which start with
+// pathPrefix, e.g. "projects/WEB/categories". An empty prefix drops all.
+func (c *Client) InvalidateCache(pathPrefix string) error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.cache.DeletePrefix(c.cache.prefix + c.BaseURL.Host + c.BaseURL.Path + pathPrefix)
+}
+
+// relatedResources are the resources whose responses change with the resource.
+var relatedResources = map[string][]string{
+	"watchings": {"users"},
+	"stars":     {"users"},
+}
+
+// send sends req through the cache.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	cc := c.cache
+	if cc == nil {
+		return c.roundTrip(req)
+	}
+
+	if req.Method != "GET" {
+		resp, err := c.roundTrip(req)
+		if err == nil && 200 <= resp.StatusCode && resp.StatusCode <= 299 {
+			c.invalidate(req.Method, c.relativePath(req))
+		}
+		return resp, err
+	}
+
+	key := cc.key(req)
+	cached, ok := cc.cache.Get(key)
+	if ok && cc.now().Before(cached.Expires) {
+		return cached.response(req), nil
+	}
+	if ok {
+		if etag := cached.Header.Get("ETag"); len(etag) > 0 {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); len(modified) > 0 {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		resp.Body.Close()
+		cached.Expires = cc.expires(c.relativePath(req), resp.Header)
+		cc.cache.Set(key, cached)
+		return cached.response(req), nil
+	}
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	expires := cc.expires(c.relativePath(req), resp.Header)
+	if expires.After(cc.now()) || len(resp.Header.Get("ETag")) > 0 || len(resp.Header.Get("Last-Modified")) > 0 {
+		cc.cache.Set(key, &CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body, Expires: expires})
+	}
+	return resp, nil
+}
+
+// invalidate drops the cached responses which a successful request other than
+// GET to relativePath may change: those of the path, the lists containing it
+// and their counts, e.g. "issues/WEB-1", "issues" and "issues/count" for
+// "issues/WEB-1". The subresources of the path are also dropped unless the
+// request is POST, which adds a resource to the list at the path.
+func (c *Client) invalidate(method, relativePath string) {
+	p := strings.Trim(relativePath, "/")
+	c.InvalidateCache(p + "?")
+	if method == "POST" {
+		c.InvalidateCache(p + "/count?")
+	} else {
+		c.InvalidateCache(p + "/")
+	}
+	for i := strings.LastIndex(p, "/"); i > 0; i = strings.LastIndex(p, "/") {
+		p = p[:i]
+		c.InvalidateCache(p + "?")
+		c.InvalidateCache(p + "/count?")
+	}
+	for _, r := range relatedResources[p] {
+		c.InvalidateCache(r)
+	}
+}
+
+// key returns the cache key of req: the API key prefix, the host, the path and
+// the sorted query without the API key.
+func (cc *clientCache) key(req *http.Request) string {
+	q := req.URL.Query()
+	q.Del("apiKey")
+	return cc.prefix + req.URL.Host + req.URL.Path + "?" + q.Encode()
+}
+
+// expires returns the time until which a response with header is fresh.
+func (cc *clientCache) expires(relativePath string, header http.Header) time.Time {
+	now := cc.now()
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "no-cache" {
+			return now
+		}
+		if strings.HasPrefix(directive, "max-age=") {
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+	for _, t := range cc.ttls {
+		if ok, _ := path.Match(t.Pattern, relativePath); ok {
+			return now.Add(t.TTL)
+		}
+	}
+	return now
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	header.Set("X-From-Cache", "1")
+	// The rate limit of the original response is stale.
+	for _, k := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+		header.Del(k)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// MemoryCache is a Cache in memory.
+type MemoryCache struct {
+	mu        sync.Mutex
+	responses map[string]*CachedResponse
+}
+
+// NewMemoryCache returns a new MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{responses: map[string]*CachedResponse{}}
+}
+
+// Get returns the response of key.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.responses[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *r
+	return &copied, true
+}
+
+// Set stores the response of key.
+func (c *MemoryCache) Set(key string, response *CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := *response
+	c.responses[key] = &copied
+	return nil
+}
+
+// DeletePrefix deletes the responses whose keys start with prefix.
+func (c *MemoryCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.responses {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.responses, key)
+		}
+	}
+	return nil
+}
+
+// DiskCache is a Cache in a directory, which is shared between runs.
+// A response is stored in a file named after the hash of its key.
+type DiskCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// diskEntry is the content of a DiskCache file.
+type diskEntry struct {
+	Key      string          `json:"key"`
+	Response *CachedResponse `json:"response"`
+}
+
+// NewDiskCache returns a new DiskCache in dir, which is created if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (c *DiskCache) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response of key. Unreadable files are treated as missing.
+func (c *DiskCache) Get(key string) (*CachedResponse, bool) {
+	data, err := ioutil.ReadFile(c.filename(key))
+	if err != nil {
+		return nil, false
+	}
+	var e diskEntry
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key || e.Response == nil {
+		return nil, false
+	}
+	return e.Response, true
+}
+
+// Set stores the response of key. The headers which carry credentials, such
+// as Set-Cookie, are not stored.
+func (c *DiskCache) Set(key string, response *CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored := *response
+	stored.Header = http.Header{}
+	for k, v := range response.Header {
+		if !IsSensitiveHeader(k) {
+			stored.Header[k] = v
+		}
+	}
+	data, err := json.Marshal(diskEntry{Key: key, Response: &stored})
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.filename(key))
+}
+
+// DeletePrefix deletes the responses whose keys start with prefix.
+func (c *DiskCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var e diskEntry
+		if json.Unmarshal(data, &e) != nil || strings.HasPrefix(e.Key, prefix) {
+			os.Remove(name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package backlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_SetCache_etag(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	client.SetCache(NewMemoryCache())
+
+	requests := 0
+	mux.HandleFunc("/api/v2/projects/WEB", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"projectKey":"WEB"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		project, resp, err := client.Projects.Get("WEB")
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if project.ProjectKey != "WEB" {
+			t.Errorf("Get returned %+v", project)
+		}
+		if fromCache := resp.Header.Get("X-From-Cache") == "1"; fromCache != (i == 1) {
+			t.Errorf("request %d: X-From-Cache is %v", i, fromCache)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
+func TestClient_SetCache_ttl(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	client.SetCache(NewMemoryCache(), MetadataCacheTTLs(time.Hour)...)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.cache.now = func() time.Time { return now }
+
+	requests := map[string]int{}
+	mux.HandleFunc("/api/v2/priorities", func(w http.ResponseWriter, r *http.Request) {
+		requests["priorities"]++
+		fmt.Fprint(w, `[{"id":2,"name":"High"}]`)
+	})
+	mux.HandleFunc("/api/v2/projects/WEB/categories", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"id":2,"name":"Backend"}`)
+			return
+		}
+		requests["categories"]++
+		fmt.Fprint(w, `[{"id":1,"name":"Frontend"}]`)
+	})
+
+	client.Space.ListPriorities()
+	client.Space.ListPriorities()
+	if requests["priorities"] != 1 {
+		t.Errorf("server received %d requests within TTL, want 1", requests["priorities"])
+	}
+	now = now.Add(time.Hour)
+	client.Space.ListPriorities()
+	if requests["priorities"] != 2 {
+		t.Errorf("server received %d requests after TTL, want 2", requests["priorities"])
+	}
+
+	client.Projects.ListCategories("WEB")
+	client.Projects.CreateCategory("WEB", "Backend")
+	client.Projects.ListCategories("WEB")
+	if requests["categories"] != 2 {
+		t.Errorf("server received %d requests with CreateCategory between, want 2", requests["categories"])
+	}
+	client.Projects.ListCategories("WEB")
+	if requests["categories"] != 2 {
+		t.Errorf("server received %d requests, want 2", requests["categories"])
+	}
+}
+
+func TestClient_SetCache_invalidate(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	client.SetCache(NewMemoryCache(),
+		CacheTTL{Pattern: "issues", TTL: time.Hour},
+		CacheTTL{Pattern: "issues/*", TTL: time.Hour},
+		CacheTTL{Pattern: "issues/*/comments", TTL: time.Hour},
+		CacheTTL{Pattern: "projects/*", TTL: time.Hour},
+	)
+
+	requests := map[string]int{}
+	mux.HandleFunc("/api/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			requests[strings.TrimPrefix(r.URL.Path, "/api/v2/")]++
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	send := func(method, u string) {
+		req, _ := client.NewRequest(method, u, nil)
+		if _, err := client.Do(req, nil); err != nil {
+			t.Fatalf("%s %s returned error: %v", method, u, err)
+		}
+	}
+	paths := []string{"issues/WEB-1", "issues/WEB-2", "issues", "issues/count", "issues/WEB-1/comments", "issues/WEB-2/comments", "projects/WEB"}
+	for _, p := range paths {
+		send("GET", p)
+	}
+
+	send("PATCH", "issues/WEB-1")
+	for _, p := range paths {
+		send("GET", p)
+	}
+	want := map[string]int{
+		"issues/WEB-1": 2, "issues": 2, "issues/count": 2, "issues/WEB-1/comments": 2,
+		"issues/WEB-2": 1, "issues/WEB-2/comments": 1, "projects/WEB": 1,
+	}
+	for p, n := range want {
+		if requests[p] != n {
+			t.Errorf("server received %d requests of %s with PATCH issues/WEB-1 between, want %d", requests[p], p, n)
+		}
+	}
+
+	send("POST", "issues/WEB-2/comments")
+	send("GET", "issues/WEB-2/comments")
+	send("GET", "issues/WEB-2")
+	send("GET", "issues/WEB-1")
+	if requests["issues/WEB-2/comments"] != 2 || requests["issues/WEB-2"] != 2 || requests["issues/WEB-1"] != 2 {
+		t.Errorf("server received %v with POST issues/WEB-2/comments between", requests)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, key := range []string{"k example.backlog.com/api/v2/priorities?", "k example.backlog.com/api/v2/projects/WEB/users?"} {
+		if err := cache.Set(key, &CachedResponse{StatusCode: 200, Body: []byte(key), Expires: expires}); err != nil {
+			t.Fatalf("Set returned error: %v", err)
+		}
+	}
+
+	// Another instance reads the files.
+	cache, _ = NewDiskCache(dir)
+	r, ok := cache.Get("k example.backlog.com/api/v2/priorities?")
+	if !ok || string(r.Body) != "k example.backlog.com/api/v2/priorities?" || !r.Expires.Equal(expires) {
+		t.Errorf("Get returned %+v, %v", r, ok)
+	}
+
+	if err := cache.DeletePrefix("k example.backlog.com/api/v2/projects"); err != nil {
+		t.Fatalf("DeletePrefix returned error: %v", err)
+	}
+	if _, ok := cache.Get("k example.backlog.com/api/v2/projects/WEB/users?"); ok {
+		t.Errorf("Get returned the deleted response")
+	}
+	if _, ok := cache.Get("k example.backlog.com/api/v2/priorities?"); !ok {
+		t.Errorf("DeletePrefix deleted a response without the prefix")
+	}
+}
+
+func TestDiskCache_sensitiveHeaders(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{"Set-Cookie": {"JSESSIONID=secret"}, "Etag": {`"v1"`}}
+	if err := cache.Set("k", &CachedResponse{StatusCode: 200, Header: header}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, name := range files {
+		if data, _ := ioutil.ReadFile(name); strings.Contains(string(data), "secret") {
+			t.Errorf("%s contains Set-Cookie: %s", name, data)
+		}
+	}
+	r, ok := cache.Get("k")
+	if !ok || r.Header.Get("ETag") != `"v1"` || len(r.Header.Get("Set-Cookie")) > 0 {
+		t.Errorf("Get returned %+v, %v", r, ok)
+	}
+	if header.Get("Set-Cookie") == "" {
+		t.Errorf("Set modified the header of the response")
+	}
+}
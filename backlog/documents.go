@@ -0,0 +1,139 @@
+package backlog
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DocumentsService is
+type DocumentsService service
+
+// Document is Backlog document in the Backlog project
+type Document struct {
+	ID          string               `json:"id"`
+	ProjectID   int                  `json:"projectId"`
+	Title       string               `json:"title"`
+	Plain       string               `json:"plain"` // 本文 (プレーンテキスト)
+	JSON        string               `json:"json"`  // 本文 (ProseMirror の JSON)
+	StatusID    int                  `json:"statusId"`
+	Emoji       string               `json:"emoji"`
+	Attachments []DocumentAttachment `json:"attachments"`
+	Tags        []DocumentTag        `json:"tags"`
+	CreatedUser User                 `json:"createdUser"`
+	Created     time.Time            `json:"created"`
+	UpdatedUser User                 `json:"updatedUser"`
+	Updated     time.Time            `json:"updated"`
+}
+
+// DocumentAttachment is a file attached to Backlog document
+type DocumentAttachment struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	CreatedUser User      `json:"createdUser"`
+	Created     time.Time `json:"created"`
+}
+
+// DocumentTag is a tag of Backlog document
+type DocumentTag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DocumentTree is the tree of documents in the Backlog project
+type DocumentTree struct {
+	ProjectID  int               `json:"projectId"`
+	ActiveTree *DocumentTreeNode `json:"activeTree"`
+	TrashTree  *DocumentTreeNode `json:"trashTree"`
+}
+
+// DocumentTreeNode is a document in DocumentTree
+type DocumentTreeNode struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Emoji    string              `json:"emoji"`
+	Children []*DocumentTreeNode `json:"children"`
+}
+
+// DocumentListRequest represents a request to list documents.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-document-list/
+type DocumentListRequest struct {
+	ProjectIDs []int   `url:"projectId[],omitempty"` // プロジェクトのID
+	Keyword    *string `url:"keyword,omitempty"`     // 検索キーワード
+	Sort       *string `url:"sort,omitempty"`        // `created` または `updated`
+	Order      *string `url:"order,omitempty"`       // `asc` または `desc` 指定が無い場合は `desc`
+	Offset     *int    `url:"offset,omitempty"`      // オフセット
+	Count      *int    `url:"count,omitempty"`       // 取得上限 (1-100) 指定が無い場合は 20
+}
+
+// List lists documents.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-document-list/
+func (s *DocumentsService) List(request DocumentListRequest) ([]*Document, *Response, error) {
+	u, _ := addOptions("documents", request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	documents := []*Document{}
+	resp, err := s.client.Do(req, &documents)
+	if err != nil {
+		return nil, resp, err
+	}
+	return documents, resp, nil
+}
+
+// Tree gets the tree of documents in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-document-tree/
+func (s *DocumentsService) Tree(projectKey string) (*DocumentTree, *Response, error) {
+	u := "documents/tree?projectIdOrKey=" + projectKey
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree := new(DocumentTree)
+	resp, err := s.client.Do(req, &tree)
+	if err != nil {
+		return nil, resp, err
+	}
+	return tree, resp, nil
+}
+
+// Get gets a document with its content and attachments.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-document/
+func (s *DocumentsService) Get(documentID string) (*Document, *Response, error) {
+	u := "documents/" + documentID
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document := new(Document)
+	resp, err := s.client.Do(req, &document)
+	if err != nil {
+		return nil, resp, err
+	}
+	return document, resp, nil
+}
+
+// DownloadAttachment writes the attached file of the document to w, and returns its file name.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-document-attachments/
+func (s *DocumentsService) DownloadAttachment(documentID string, attachmentID int, w io.Writer) (string, *Response, error) {
+	u := fmt.Sprintf("documents/%s/attachments/%d", documentID, attachmentID)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := s.client.Do(req, w)
+	if err != nil {
+		return "", resp, err
+	}
+	return filename(resp), resp, nil
+}
@@ -0,0 +1,129 @@
+package backlog
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestDocumentsService_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/documents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if got, want := q.Get("projectId[]"), "1"; got != want {
+			t.Errorf("projectId[]: %v, want %v", got, want)
+		}
+		if got, want := q.Get("keyword"), "release"; got != want {
+			t.Errorf("keyword: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"id":"0192ff","projectId":1,"title":"Release notes","plain":"v2.1",
+			"tags":[{"id":2,"name":"release"}],"attachments":[{"id":7,"name":"notes.pdf","size":2048}]}]`)
+	})
+
+	documents, _, err := client.Documents.List(DocumentListRequest{ProjectIDs: []int{1}, Keyword: pointers.String("release")})
+	if err != nil {
+		t.Fatalf("Documents.List returned error: %v", err)
+	}
+	if len(documents) != 1 {
+		t.Fatalf("Documents.List returned %d documents, want 1", len(documents))
+	}
+	d := documents[0]
+	if d.ID != "0192ff" || d.Title != "Release notes" || len(d.Tags) != 1 || len(d.Attachments) != 1 || d.Attachments[0].Size != 2048 {
+		t.Errorf("Documents.List returned %+v", d)
+	}
+}
+
+func TestDocumentsService_Tree(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/documents/tree", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("projectIdOrKey"), "TEST"; got != want {
+			t.Errorf("projectIdOrKey: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"projectId":1,
+			"activeTree":{"id":"Active","children":[{"id":"a1","name":"Guide","children":[{"id":"a2","name":"Setup","children":[]}]}]},
+			"trashTree":{"id":"Trash","children":[]}}`)
+	})
+
+	tree, _, err := client.Documents.Tree("TEST")
+	if err != nil {
+		t.Fatalf("Documents.Tree returned error: %v", err)
+	}
+	if tree.ActiveTree == nil || len(tree.ActiveTree.Children) != 1 {
+		t.Fatalf("Documents.Tree returned %+v", tree)
+	}
+	guide := tree.ActiveTree.Children[0]
+	if guide.Name != "Guide" || len(guide.Children) != 1 || guide.Children[0].Name != "Setup" {
+		t.Errorf("DocumentTree.ActiveTree.Children[0] is %+v", guide)
+	}
+}
+
+func TestDocumentsService_Get(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/documents/0192ff", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"0192ff","title":"Release notes","plain":"v2.1","json":"{\"type\":\"doc\"}"}`)
+	})
+
+	document, _, err := client.Documents.Get("0192ff")
+	if err != nil {
+		t.Fatalf("Documents.Get returned error: %v", err)
+	}
+	if document.Plain != "v2.1" || document.JSON != `{"type":"doc"}` {
+		t.Errorf("Documents.Get returned %+v", document)
+	}
+}
+
+func TestDocumentsService_DownloadAttachment(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/documents/0192ff/attachments/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="notes.pdf"`)
+		fmt.Fprint(w, "%PDF-1.7")
+	})
+
+	var buf bytes.Buffer
+	name, _, err := client.Documents.DownloadAttachment("0192ff", 7, &buf)
+	if err != nil {
+		t.Fatalf("Documents.DownloadAttachment returned error: %v", err)
+	}
+	if name != "notes.pdf" {
+		t.Errorf("Documents.DownloadAttachment returned file name %q, want notes.pdf", name)
+	}
+	if got := buf.String(); got != "%PDF-1.7" {
+		t.Errorf("Documents.DownloadAttachment wrote %q", got)
+	}
+}
+
+func TestDocumentsService_DownloadAttachment_notFound(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/documents/0192ff/attachments/8", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"No attachment.","code":6,"moreInfo":""}]}`)
+	})
+
+	var buf bytes.Buffer
+	_, _, err := client.Documents.DownloadAttachment("0192ff", 8, &buf)
+	if e, ok := err.(*ErrorResponse); !ok || len(e.Errors) != 1 || e.Errors[0].Code != 6 {
+		t.Errorf("Documents.DownloadAttachment returned %v, want *ErrorResponse", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Documents.DownloadAttachment wrote the error body %q", buf.String())
+	}
+}
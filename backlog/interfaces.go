@@ -0,0 +1,120 @@
+package backlog
+
+import "io"
+
+// The interfaces below allow code using the services to replace them with
+// fakes. backlogmock provides mocks generated from them.
+//
+//go:generate go run ./internal/mockgen -o backlogmock/mock.go
+
+// IssuesAPI is the interface of IssuesService.
+type IssuesAPI interface {
+	Get(issueKey string) (*Issue, *Response, error)
+	Create(request IssueRequest) (*Issue, *Response, error)
+	Edit(issueKey string, request IssueRequest) (*Issue, *Response, error)
+	Delete(issueKey string) (*Response, error)
+	Search(request IssueSearchRequest) ([]*Issue, *Response, error)
+	ListComments(issueKey string, order string) ([]*IssueComment, *Response, error)
+	CreateComment(issueKey string, comment string) (*IssueComment, *Response, error)
+	ListParticipants(issueKey string) ([]*User, *Response, error)
+	SearchAll(request IssueSearchRequest) ([]*Issue, *Response, error)
+	ListChildren(parentKey string) ([]*Issue, *Response, error)
+	GetParent(issueKey string) (*Issue, *Response, error)
+	Tree(projectKey string) ([]*IssueNode, *Response, error)
+	Query(query string, r *Resolver) ([]*Issue, *Response, error)
+	Watch(issueKey string, note string) (*Watching, *Response, error)
+	Unwatch(userID int, issueKey string) (*Watching, *Response, error)
+	GetMany(keys []string, opt *BulkOptions) []IssueResult
+	BulkEdit(search IssueSearchRequest, change IssueRequest, opt *BulkEditOptions) (*BulkEditReport, error)
+}
+
+// ProjectsAPI is the interface of ProjectsService.
+type ProjectsAPI interface {
+	ListAll() ([]*Project, *Response, error)
+	Get(projectKey string) (*Project, *Response, error)
+	ListIssueTypes(projectKey string) ([]*IssueType, *Response, error)
+	ListCategories(projectKey string) ([]*Category, *Response, error)
+	ListVersions(projectKey string) ([]*Version, *Response, error)
+	ListUsers(projectKey string) ([]*User, *Response, error)
+	CreateCategory(projectKey string, categoryName string) (*Category, *Response, error)
+	DeleteCategory(projectKey string, categoryID string) (*Response, error)
+	CreateIssueType(projectKey string, name string, color string) (*IssueType, *Response, error)
+	DeleteIssueType(projectKey string, issueTypeID string, substituteIssueTypeID string) (*Response, error)
+	ListWebhooks(projectKey string) ([]*Webhook, *Response, error)
+	GetWebhook(projectKey string, webhookID int) (*Webhook, *Response, error)
+	CreateWebhook(projectKey string, request WebhookRequest) (*Webhook, *Response, error)
+	UpdateWebhook(projectKey string, webhookID int, request WebhookRequest) (*Webhook, *Response, error)
+	DeleteWebhook(projectKey string, webhookID int) (*Webhook, *Response, error)
+	GetMetadata(projectKeys []string, opt *BulkOptions) []ProjectMetadata
+	ListActivities(projectKey string, request ActivityListRequest) ([]*Activity, *Response, error)
+}
+
+// SpaceAPI is the interface of SpaceService.
+type SpaceAPI interface {
+	ListPriorities() ([]*Priority, *Response, error)
+	ListStatuses() ([]*Status, *Response, error)
+	ListResolutions() ([]*Resolution, *Response, error)
+	Get() (*Space, *Response, error)
+	GetNotification() (*SpaceNotification, *Response, error)
+	UpdateNotification(content string) (*SpaceNotification, *Response, error)
+	GetDiskUsage() (*DiskUsage, *Response, error)
+	GetLicence() (*Licence, *Response, error)
+	DownloadLogo(w io.Writer) (string, *Response, error)
+	RateLimit() (*RateLimits, *Response, error)
+	CheckRateLimit(bucket RateLimitBucket, n int) (*RateLimit, *Response, error)
+	ListActivities(request ActivityListRequest) ([]*Activity, *Response, error)
+}
+
+// UsersAPI is the interface of UsersService.
+type UsersAPI interface {
+	ListActivities(userID int, request ActivityListRequest) ([]*Activity, *Response, error)
+	Myself() (*User, *Response, error)
+}
+
+// NotificationsAPI is the interface of NotificationsService.
+type NotificationsAPI interface {
+	List(request NotificationListRequest) ([]*Notification, *Response, error)
+	Count(request NotificationCountRequest) (int, *Response, error)
+	ResetUnreadCount() (int, *Response, error)
+	MarkAsRead(notificationID int) (*Response, error)
+}
+
+// WatchingsAPI is the interface of WatchingsService.
+type WatchingsAPI interface {
+	List(userID int, request WatchingListRequest) ([]*Watching, *Response, error)
+	Count(userID int, request WatchingCountRequest) (int, *Response, error)
+	Get(watchingID int) (*Watching, *Response, error)
+	Add(issueIDOrKey string, note string) (*Watching, *Response, error)
+	Update(watchingID int, note string) (*Watching, *Response, error)
+	Delete(watchingID int) (*Watching, *Response, error)
+	MarkAsRead(watchingID int) (*Response, error)
+}
+
+// StarsAPI is the interface of StarsService.
+type StarsAPI interface {
+	Add(request StarRequest) (*Response, error)
+	Remove(starID int) (*Response, error)
+	ListReceived(userID int, request StarListRequest) ([]*Star, *Response, error)
+	CountReceived(userID int, request StarCountRequest) (int, *Response, error)
+}
+
+// DocumentsAPI is the interface of DocumentsService.
+type DocumentsAPI interface {
+	List(request DocumentListRequest) ([]*Document, *Response, error)
+	Tree(projectKey string) (*DocumentTree, *Response, error)
+	Get(documentID string) (*Document, *Response, error)
+	DownloadAttachment(documentID string, attachmentID int, w io.Writer) (string, *Response, error)
+}
+
+// Compile-time assertions that the services implement the interfaces.
+// TestInterfacesInSync checks that the interfaces have all the methods.
+var (
+	_ IssuesAPI        = (*IssuesService)(nil)
+	_ ProjectsAPI      = (*ProjectsService)(nil)
+	_ SpaceAPI         = (*SpaceService)(nil)
+	_ UsersAPI         = (*UsersService)(nil)
+	_ NotificationsAPI = (*NotificationsService)(nil)
+	_ WatchingsAPI     = (*WatchingsService)(nil)
+	_ StarsAPI         = (*StarsService)(nil)
+	_ DocumentsAPI     = (*DocumentsService)(nil)
+)
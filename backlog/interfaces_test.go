@@ -0,0 +1,49 @@
+package backlog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestInterfacesInSync checks that every service of Client has an interface
+// and every exported method of the services is in their interfaces, which the
+// compile-time assertions do not check.
+func TestInterfacesInSync(t *testing.T) {
+	tests := []struct {
+		service interface{}
+		api     interface{}
+	}{
+		{(*IssuesService)(nil), (*IssuesAPI)(nil)},
+		{(*ProjectsService)(nil), (*ProjectsAPI)(nil)},
+		{(*SpaceService)(nil), (*SpaceAPI)(nil)},
+		{(*UsersService)(nil), (*UsersAPI)(nil)},
+		{(*NotificationsService)(nil), (*NotificationsAPI)(nil)},
+		{(*WatchingsService)(nil), (*WatchingsAPI)(nil)},
+		{(*StarsService)(nil), (*StarsAPI)(nil)},
+		{(*DocumentsService)(nil), (*DocumentsAPI)(nil)},
+	}
+
+	covered := map[reflect.Type]bool{}
+	for _, tt := range tests {
+		covered[reflect.TypeOf(tt.service)] = true
+	}
+	client := reflect.TypeOf(Client{})
+	for i := 0; i < client.NumField(); i++ {
+		f := client.Field(i)
+		if f.Type.Kind() == reflect.Ptr && strings.HasSuffix(f.Type.Elem().Name(), "Service") && !covered[f.Type] {
+			t.Errorf("Client.%s has no interface", f.Name)
+		}
+	}
+
+	for _, tt := range tests {
+		service := reflect.TypeOf(tt.service)
+		api := reflect.TypeOf(tt.api).Elem()
+		for i := 0; i < service.NumMethod(); i++ {
+			m := service.Method(i)
+			if _, ok := api.MethodByName(m.Name); !ok {
+				t.Errorf("%v.%s is not in %v; add it and run go generate", service.Elem().Name(), m.Name, api.Name())
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Command mockgen generates the backlogmock package from the service
+// interfaces in interfaces.go.
+//
+//	go generate ./backlog
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const backlogImport = "github.com/mnkd/go-backlog/backlog"
+
+// imports maps the package names in the interfaces to their import paths.
+var imports = map[string]string{
+	"io":   "io",
+	"http": "net/http",
+	"url":  "net/url",
+	"time": "time",
+}
+
+func main() {
+	in := flag.String("i", "interfaces.go", "input file")
+	out := flag.String("o", "backlogmock/mock.go", "output file")
+	flag.Parse()
+
+	src, err := generate(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(path string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	used := map[string]bool{}
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			names = append(names, ts.Name.Name)
+			if err := writeMock(&body, ts.Name.Name, it, used); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s. DO NOT EDIT.\n\n", filepath.Base(path))
+	fmt.Fprintf(&buf, "// Package backlogmock provides mocks of the backlog service interfaces.\n")
+	fmt.Fprintf(&buf, "// Set the Func field of each method called by the code under test;\n")
+	fmt.Fprintf(&buf, "// calling a method whose Func is nil panics.\n")
+	fmt.Fprintf(&buf, "package backlogmock\n\nimport (\n")
+	var pkgs []string
+	for pkg := range used {
+		pkgs = append(pkgs, imports[pkg])
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		fmt.Fprintf(&buf, "\t%q\n", pkg)
+	}
+	fmt.Fprintf(&buf, "\n\t%q\n)\n\n", backlogImport)
+	fmt.Fprintf(&buf, "var (\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t_ backlog.%s = (*%s)(nil)\n", name, name)
+	}
+	fmt.Fprintf(&buf, ")\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func writeMock(w *bytes.Buffer, name string, it *ast.InterfaceType, used map[string]bool) error {
+	type method struct {
+		name, params, args, results string
+	}
+	var methods []method
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return fmt.Errorf("%s: embedded interfaces are not supported", name)
+		}
+		m := method{name: field.Names[0].Name}
+		var params, args []string
+		for i, p := range ft.Params.List {
+			typ := typeString(p.Type, used)
+			pnames := p.Names
+			if len(pnames) == 0 {
+				pnames = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", i))}
+			}
+			for _, n := range pnames {
+				params = append(params, n.Name+" "+typ)
+				if _, ok := p.Type.(*ast.Ellipsis); ok {
+					args = append(args, n.Name+"...")
+				} else {
+					args = append(args, n.Name)
+				}
+			}
+		}
+		var results []string
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				results = append(results, typeString(r.Type, used))
+			}
+		}
+		m.params = strings.Join(params, ", ")
+		m.args = strings.Join(args, ", ")
+		m.results = strings.Join(results, ", ")
+		if len(results) > 1 {
+			m.results = "(" + m.results + ")"
+		}
+		methods = append(methods, m)
+	}
+
+	fmt.Fprintf(w, "\n// %s is a mock of backlog.%s.\n", name, name)
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t%sFunc func(%s) %s\n", m.name, m.params, m.results)
+	}
+	fmt.Fprintf(w, "}\n")
+	for _, m := range methods {
+		fmt.Fprintf(w, "\n// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", name, m.name, m.params, m.results)
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n\t\tpanic(\"backlogmock: %s.%sFunc is not set\")\n\t}\n", m.name, name, m.name)
+		fmt.Fprintf(w, "\treturn m.%sFunc(%s)\n}\n", m.name, m.args)
+	}
+	return nil
+}
+
+// typeString formats expr qualifying the types of the backlog package.
+func typeString(expr ast.Expr, used map[string]bool) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return "backlog." + e.Name
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		pkg := e.X.(*ast.Ident).Name
+		used[pkg] = true
+		return pkg + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(e.X, used)
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return "[" + e.Len.(*ast.BasicLit).Value + "]" + typeString(e.Elt, used)
+		}
+		return "[]" + typeString(e.Elt, used)
+	case *ast.MapType:
+		return "map[" + typeString(e.Key, used) + "]" + typeString(e.Value, used)
+	case *ast.Ellipsis:
+		return "..." + typeString(e.Elt, used)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	panic(fmt.Sprintf("mockgen: unsupported type %T", expr))
+}
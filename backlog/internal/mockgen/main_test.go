@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+// TestGenerate checks that backlogmock is regenerated after the interfaces change.
+func TestGenerate(t *testing.T) {
+	got, err := generate("../../interfaces.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ioutil.ReadFile("../../backlogmock/mock.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("backlogmock/mock.go is out of date; run go generate ./backlog")
+	}
+}
@@ -0,0 +1,217 @@
+package backlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IssueBuilder builds an IssueRequest from the names of the issue type,
+// priority, users and so on, which are resolved into IDs by a Resolver.
+//
+//	b := backlog.NewIssue("PROJ").Type("Bug").Priority("High").Assignee("alice").
+//		Milestone("v2.1").Summary("Login fails")
+//	request, err := b.BuildCreate(resolver)
+//	issue, _, err := client.Issues.Create(request)
+type IssueBuilder struct {
+	projectKey string
+
+	summary       *string
+	description   *string
+	issueType     *string
+	priority      *string
+	status        *string
+	category      *string
+	version       *string
+	milestone     *string
+	assignee      *string
+	parentIssueID *int
+	startDate     *time.Time
+	dueDate       *time.Time
+}
+
+// NewIssue returns a new IssueBuilder for an issue in the project.
+func NewIssue(projectKey string) *IssueBuilder {
+	return &IssueBuilder{projectKey: projectKey}
+}
+
+// Summary sets the summary.
+func (b *IssueBuilder) Summary(summary string) *IssueBuilder {
+	b.summary = &summary
+	return b
+}
+
+// Description sets the description.
+func (b *IssueBuilder) Description(description string) *IssueBuilder {
+	b.description = &description
+	return b
+}
+
+// Type sets the name of the issue type.
+func (b *IssueBuilder) Type(name string) *IssueBuilder {
+	b.issueType = &name
+	return b
+}
+
+// Priority sets the name of the priority.
+func (b *IssueBuilder) Priority(name string) *IssueBuilder {
+	b.priority = &name
+	return b
+}
+
+// Status sets the name of the status.
+func (b *IssueBuilder) Status(name string) *IssueBuilder {
+	b.status = &name
+	return b
+}
+
+// Category sets the name of the category.
+func (b *IssueBuilder) Category(name string) *IssueBuilder {
+	b.category = &name
+	return b
+}
+
+// Version sets the name of the version.
+func (b *IssueBuilder) Version(name string) *IssueBuilder {
+	b.version = &name
+	return b
+}
+
+// Milestone sets the name of the milestone.
+func (b *IssueBuilder) Milestone(name string) *IssueBuilder {
+	b.milestone = &name
+	return b
+}
+
+// Assignee sets the assignee by the user ID, the name or the mail address.
+func (b *IssueBuilder) Assignee(user string) *IssueBuilder {
+	b.assignee = &user
+	return b
+}
+
+// Parent sets the ID of the parent issue.
+func (b *IssueBuilder) Parent(issueID int) *IssueBuilder {
+	b.parentIssueID = &issueID
+	return b
+}
+
+// StartDate sets the start date.
+func (b *IssueBuilder) StartDate(date time.Time) *IssueBuilder {
+	b.startDate = &date
+	return b
+}
+
+// DueDate sets the due date.
+func (b *IssueBuilder) DueDate(date time.Time) *IssueBuilder {
+	b.dueDate = &date
+	return b
+}
+
+// IssueBuildError reports all the problems found by IssueBuilder.
+type IssueBuildError struct {
+	Errors []error
+}
+
+func (e *IssueBuildError) Error() string {
+	s := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		s = append(s, err.Error())
+	}
+	return "invalid issue: " + strings.Join(s, "; ")
+}
+
+// BuildCreate builds an IssueRequest for IssuesService.Create.
+// The summary, the issue type and the priority are required.
+func (b *IssueBuilder) BuildCreate(r *Resolver) (IssueRequest, error) {
+	var errs []error
+	if b.summary == nil || len(strings.TrimSpace(*b.summary)) == 0 {
+		errs = append(errs, fmt.Errorf("summary is required"))
+	}
+	if b.issueType == nil {
+		errs = append(errs, fmt.Errorf("issue type is required"))
+	}
+	if b.priority == nil {
+		errs = append(errs, fmt.Errorf("priority is required"))
+	}
+
+	request, resolveErrs := b.build(r)
+	errs = append(errs, resolveErrs...)
+
+	projectID, err := r.ProjectID(b.projectKey)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		request.ProjectID = &projectID
+	}
+
+	if len(errs) > 0 {
+		return IssueRequest{}, &IssueBuildError{Errors: errs}
+	}
+	return request, nil
+}
+
+// BuildEdit builds an IssueRequest for IssuesService.Edit.
+// Only the fields set to the builder are changed.
+func (b *IssueBuilder) BuildEdit(r *Resolver) (IssueRequest, error) {
+	var errs []error
+	if b.summary != nil && len(strings.TrimSpace(*b.summary)) == 0 {
+		errs = append(errs, fmt.Errorf("summary must not be empty"))
+	}
+
+	request, resolveErrs := b.build(r)
+	errs = append(errs, resolveErrs...)
+
+	if len(errs) > 0 {
+		return IssueRequest{}, &IssueBuildError{Errors: errs}
+	}
+	if len(request.makeValues()) == 0 {
+		return IssueRequest{}, &IssueBuildError{Errors: []error{fmt.Errorf("nothing to change")}}
+	}
+	return request, nil
+}
+
+func (b *IssueBuilder) build(r *Resolver) (IssueRequest, []error) {
+	var errs []error
+	request := IssueRequest{
+		Summary:       b.summary,
+		Description:   b.description,
+		ParentIssueID: b.parentIssueID,
+	}
+
+	resolve := func(name *string, dst **int, fn func(string) (int, error)) {
+		if name == nil {
+			return
+		}
+		id, err := fn(*name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		*dst = &id
+	}
+	inProject := func(fn func(string, string) (int, error)) func(string) (int, error) {
+		return func(name string) (int, error) { return fn(b.projectKey, name) }
+	}
+
+	resolve(b.issueType, &request.IssueTypeID, inProject(r.IssueTypeID))
+	resolve(b.priority, &request.PriorityID, r.PriorityID)
+	resolve(b.status, &request.StatusID, r.StatusID)
+	resolve(b.category, &request.CategoryID, inProject(r.CategoryID))
+	resolve(b.version, &request.VersionID, inProject(r.VersionID))
+	resolve(b.milestone, &request.MilestoneID, inProject(r.VersionID))
+	resolve(b.assignee, &request.AssigneeID, inProject(r.UserID))
+
+	if b.startDate != nil {
+		s := b.startDate.Format("2006-01-02")
+		request.StartDate = &s
+	}
+	if b.dueDate != nil {
+		s := b.dueDate.Format("2006-01-02")
+		request.DueDate = &s
+	}
+	if b.startDate != nil && b.dueDate != nil && b.dueDate.Before(*b.startDate) {
+		errs = append(errs, fmt.Errorf("due date %v is before start date %v", *request.DueDate, *request.StartDate))
+	}
+
+	return request, errs
+}
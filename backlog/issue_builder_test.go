@@ -0,0 +1,76 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupMetadata registers handlers for the metadata of the WEB project.
+func setupMetadata(mux *http.ServeMux) {
+	responses := map[string]string{
+		"/api/v2/projects/WEB":            `{"id":1,"projectKey":"WEB","name":"Web"}`,
+		"/api/v2/projects/WEB/issueTypes": `[{"id":11,"name":"Bug"},{"id":12,"name":"Task"}]`,
+		"/api/v2/projects/WEB/categories": `[{"id":21,"name":"Auth"}]`,
+		"/api/v2/projects/WEB/versions":   `[{"id":31,"name":"v2.0"},{"id":32,"name":"v2.1"}]`,
+		"/api/v2/projects/WEB/users":      `[{"id":1,"userId":"alice","name":"Alice"},{"id":2,"userId":"bob","name":"Bob"}]`,
+		"/api/v2/priorities":              `[{"id":2,"name":"High"},{"id":3,"name":"Normal"},{"id":4,"name":"Low"}]`,
+		"/api/v2/statuses":                `[{"id":1,"name":"Open"},{"id":2,"name":"In Progress"},{"id":3,"name":"Resolved"},{"id":4,"name":"Closed"}]`,
+		"/api/v2/resolutions":             `[{"id":0,"name":"Fixed"},{"id":1,"name":"Won't Fix"}]`,
+	}
+	for path, body := range responses {
+		body := body
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+	}
+}
+
+func TestIssueBuilder_BuildCreate(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	setupMetadata(mux)
+	resolver := NewResolver(client, 0)
+
+	request, err := NewIssue("WEB").Type("bug").Priority("High").Assignee("alice").
+		Milestone("v2.1").Summary("Login fails").DueDate(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)).
+		BuildCreate(resolver)
+	if err != nil {
+		t.Fatalf("BuildCreate returned error: %v", err)
+	}
+
+	got := request.makeValues().Encode()
+	want := "assigneeId=1&dueDate=2026-11-01&issueTypeId=11&milestoneId%5B%5D=32&priorityId=2&projectId=1&summary=Login+fails"
+	if got != want {
+		t.Errorf("BuildCreate built %v, want %v", got, want)
+	}
+}
+
+func TestIssueBuilder_errors(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	setupMetadata(mux)
+	resolver := NewResolver(client, 0)
+
+	_, err := NewIssue("WEB").Type("Story").Assignee("carol").BuildCreate(resolver)
+	e, ok := err.(*IssueBuildError)
+	if !ok {
+		t.Fatalf("BuildCreate returned %v, want *IssueBuildError", err)
+	}
+	msg := e.Error()
+	for _, want := range []string{"summary is required", "priority is required", `unknown issue type "Story"`, `unknown user "carol"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("IssueBuildError %q does not contain %q", msg, want)
+		}
+	}
+
+	if _, err := NewIssue("WEB").BuildEdit(resolver); err == nil {
+		t.Error("BuildEdit without changes returned nil error")
+	}
+	request, err := NewIssue("WEB").Status("in progress").BuildEdit(resolver)
+	if err != nil || request.StatusID == nil || *request.StatusID != 2 || request.ProjectID != nil {
+		t.Errorf("BuildEdit returned %+v, %v", request, err)
+	}
+}
@@ -19,6 +19,7 @@ type IssueComment struct {
 	} `json:"createdUser"`
 
 	ChangeLogs []ChangeLog `json:"changeLog"`
+	Stars      []Star      `json:"stars"`
 }
 
 // ChangeLog is Backlog issue comment
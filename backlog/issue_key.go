@@ -0,0 +1,183 @@
+package backlog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IssueKey is the key of Backlog issue such as "WEB-12", which consists of
+// the project key and the key ID.
+type IssueKey struct {
+	projectKey string
+	keyID      int
+}
+
+// projectKeyPattern is the format of project keys. Backlog project keys consist
+// of upper case letters, digits and underscores, and start with a letter.
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// ValidProjectKey reports whether s is a valid project key.
+func ValidProjectKey(s string) bool {
+	return projectKeyPattern.MatchString(s)
+}
+
+// NewIssueKey returns the issue key of the project key and the key ID.
+func NewIssueKey(projectKey string, keyID int) (IssueKey, error) {
+	if !ValidProjectKey(projectKey) {
+		return IssueKey{}, fmt.Errorf("invalid project key %q: must be upper case letters, digits and underscores starting with a letter", projectKey)
+	}
+	if keyID < 1 {
+		return IssueKey{}, fmt.Errorf("invalid key ID %d: must be positive", keyID)
+	}
+	return IssueKey{projectKey: projectKey, keyID: keyID}, nil
+}
+
+// ParseIssueKey parses an issue key such as "WEB-12".
+func ParseIssueKey(s string) (IssueKey, error) {
+	i := strings.LastIndex(s, "-")
+	if i < 0 {
+		return IssueKey{}, fmt.Errorf("invalid issue key %q: missing '-'", s)
+	}
+	id := s[i+1:]
+	keyID, err := strconv.Atoi(id)
+	if err != nil || len(id) == 0 || id[0] < '1' || id[0] > '9' {
+		return IssueKey{}, fmt.Errorf("invalid issue key %q: key ID must be a positive number", s)
+	}
+	key, err := NewIssueKey(s[:i], keyID)
+	if err != nil {
+		return IssueKey{}, fmt.Errorf("invalid issue key %q: %v", s, err)
+	}
+	return key, nil
+}
+
+// MustParseIssueKey is like ParseIssueKey but panics if s is invalid.
+func MustParseIssueKey(s string) IssueKey {
+	key, err := ParseIssueKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// ProjectKey returns the project key, e.g. "WEB" for "WEB-12".
+func (k IssueKey) ProjectKey() string {
+	return k.projectKey
+}
+
+// KeyID returns the key ID, e.g. 12 for "WEB-12".
+func (k IssueKey) KeyID() int {
+	return k.keyID
+}
+
+// IsZero reports whether k is the zero IssueKey.
+func (k IssueKey) IsZero() bool {
+	return k.keyID == 0
+}
+
+func (k IssueKey) String() string {
+	if k.IsZero() {
+		return ""
+	}
+	return k.projectKey + "-" + strconv.Itoa(k.keyID)
+}
+
+// IssueKeyExtractor finds issue keys in free text such as commit messages,
+// pull request titles and branch names.
+//
+// Keys in code blocks (``` and ~~~ fences, inline `code` and {code}{/code})
+// and in URLs are ignored.
+type IssueKeyExtractor struct {
+	// ProjectKeys restricts the keys to the projects. If empty, keys of any project are found.
+	ProjectKeys []string
+
+	// IgnoreCase also finds lower case keys such as "web-12" in branch names.
+	// It is recommended to set ProjectKeys with IgnoreCase, since words like
+	// "utf-8" look like issue keys.
+	IgnoreCase bool
+}
+
+var (
+	issueKeyPattern         = regexp.MustCompile(`[A-Z][A-Z0-9_]*-[1-9][0-9]*`)
+	issueKeyPatternFoldCase = regexp.MustCompile(`(?i)[A-Z][A-Z0-9_]*-[1-9][0-9]*`)
+
+	ignoredTextPatterns = []*regexp.Regexp{
+		regexp.MustCompile("(?s)```.*?(```|$)"),
+		regexp.MustCompile("(?s)~~~.*?(~~~|$)"),
+		regexp.MustCompile(`(?s)\{code(:[^}]*)?\}.*?(\{/code\}|$)`),
+		regexp.MustCompile("`[^`\n]*`"),
+		regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.\-]*://[^\s<>"]+`),
+	}
+)
+
+// ExtractIssueKeys returns the issue keys in text in order of appearance without duplicates.
+func ExtractIssueKeys(text string) []IssueKey {
+	return (&IssueKeyExtractor{}).Extract(text)
+}
+
+// Extract returns the issue keys in text in order of appearance without duplicates.
+func (e *IssueKeyExtractor) Extract(text string) []IssueKey {
+	keys := []IssueKey{}
+	seen := map[IssueKey]bool{}
+	for _, m := range e.ExtractMatches(text) {
+		if !seen[m.Key] {
+			seen[m.Key] = true
+			keys = append(keys, m.Key)
+		}
+	}
+	return keys
+}
+
+// IssueKeyMatch is an issue key found in text.
+type IssueKeyMatch struct {
+	Key        IssueKey
+	Start, End int // byte offsets of the key in text
+}
+
+// ExtractMatches returns all the issue keys in text with their positions.
+func (e *IssueKeyExtractor) ExtractMatches(text string) []IssueKeyMatch {
+	for _, re := range ignoredTextPatterns {
+		text = re.ReplaceAllStringFunc(text, func(s string) string {
+			return strings.Repeat(" ", len(s))
+		})
+	}
+
+	pattern := issueKeyPattern
+	if e.IgnoreCase {
+		pattern = issueKeyPatternFoldCase
+	}
+
+	matches := []IssueKeyMatch{}
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		if loc[0] > 0 && isIssueKeyChar(text[loc[0]-1]) {
+			continue
+		}
+		if loc[1] < len(text) && isIssueKeyChar(text[loc[1]]) {
+			continue
+		}
+
+		key, err := ParseIssueKey(strings.ToUpper(text[loc[0]:loc[1]]))
+		if err != nil || !e.allows(key) {
+			continue
+		}
+		matches = append(matches, IssueKeyMatch{Key: key, Start: loc[0], End: loc[1]})
+	}
+	return matches
+}
+
+func (e *IssueKeyExtractor) allows(key IssueKey) bool {
+	if len(e.ProjectKeys) == 0 {
+		return true
+	}
+	for _, k := range e.ProjectKeys {
+		if k == key.ProjectKey() {
+			return true
+		}
+	}
+	return false
+}
+
+func isIssueKeyChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,76 @@
+package backlog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIssueKey(t *testing.T) {
+	tests := []struct {
+		in         string
+		projectKey string
+		keyID      int
+		valid      bool
+	}{
+		{"WEB-12", "WEB", 12, true},
+		{"MY_PROJ2-1", "MY_PROJ2", 1, true},
+		{"web-12", "", 0, false},
+		{"2WEB-12", "", 0, false},
+		{"WEB-0", "", 0, false},
+		{"WEB-012", "", 0, false},
+		{"WEB-", "", 0, false},
+		{"WEB12", "", 0, false},
+		{"-12", "", 0, false},
+		{"WEB-1-2", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		key, err := ParseIssueKey(tt.in)
+		if (err == nil) != tt.valid {
+			t.Errorf("ParseIssueKey(%q) returned error %v, want valid:%v", tt.in, err, tt.valid)
+			continue
+		}
+		if key.ProjectKey() != tt.projectKey || key.KeyID() != tt.keyID {
+			t.Errorf("ParseIssueKey(%q) returned %q %d", tt.in, key.ProjectKey(), key.KeyID())
+		}
+		if tt.valid && key.String() != tt.in {
+			t.Errorf("ParseIssueKey(%q).String() returned %q", tt.in, key.String())
+		}
+	}
+}
+
+func TestExtractIssueKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"fixes WEB-12 and API-3, refs WEB-12", []string{"WEB-12", "API-3"}},
+		{"feature/WEB-12-login", []string{"WEB-12"}},
+		{"[WEB-12] (API-3): WEB-4.", []string{"WEB-12", "API-3", "WEB-4"}},
+		{"xWEB-12 WEB-12x WEB-12_a", []string{}},
+		{"see https://example.backlog.com/view/WEB-12 for WEB-13", []string{"WEB-13"}},
+		{"run `make WEB-1` then WEB-2", []string{"WEB-2"}},
+		{"WEB-1\n```\nWEB-2\n```\n~~~\nWEB-3\n~~~\n{code:go}WEB-4{/code} WEB-5", []string{"WEB-1", "WEB-5"}},
+		{"web-12", []string{}},
+	}
+
+	for _, tt := range tests {
+		got := []string{}
+		for _, k := range ExtractIssueKeys(tt.in) {
+			got = append(got, k.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractIssueKeys(%q) returned %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIssueKeyExtractor_ignoreCase(t *testing.T) {
+	e := &IssueKeyExtractor{ProjectKeys: []string{"WEB"}, IgnoreCase: true}
+	got := e.Extract("feature/web-12-utf-8 API-3 Web-4")
+
+	want := []IssueKey{MustParseIssueKey("WEB-12"), MustParseIssueKey("WEB-4")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract returned %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,545 @@
+package backlog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// IssueQuery is a human-readable issue search query such as
+//
+//	project:WEB status:open,"in progress" assignee:me milestone:"v2.1" due<2026-11-01 sort:updated
+//
+// A query is a list of terms separated by spaces. A term is one of
+//
+//	field:value[,value...]  e.g. status:open,"in progress"
+//	date-field OP yyyy-MM-dd  OP is one of : < <= > >=, e.g. due<2026-11-01
+//	keyword                  e.g. login or "login error"
+//
+// The fields are project, status, priority, resolution, type, category,
+// milestone, version, assignee, author, start, due, created, updated, sort,
+// order and limit. assignee:me and author:me mean the owner of the API key.
+// Values and keywords with spaces, commas or quotes are double-quoted.
+type IssueQuery struct {
+	Terms []QueryTerm
+}
+
+// QueryTerm is a term of IssueQuery. Field and Op are empty for keywords.
+type QueryTerm struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// QueryError reports a syntax error in an issue query.
+type QueryError struct {
+	Query string
+	Pos   int // byte offset in Query
+	Msg   string
+}
+
+func (e *QueryError) Error() string {
+	col := utf8.RuneCountInString(e.Query[:e.Pos])
+	return fmt.Sprintf("invalid query at column %d: %s\n  %s\n  %s^", col+1, e.Msg, e.Query, strings.Repeat(" ", col))
+}
+
+type queryFieldKind int
+
+const (
+	listField queryFieldKind = iota
+	dateField
+	sortField
+	orderField
+	limitField
+)
+
+var queryFields = map[string]queryFieldKind{
+	"project":    listField,
+	"status":     listField,
+	"priority":   listField,
+	"resolution": listField,
+	"type":       listField,
+	"category":   listField,
+	"milestone":  listField,
+	"version":    listField,
+	"assignee":   listField,
+	"author":     listField,
+	"start":      dateField,
+	"due":        dateField,
+	"created":    dateField,
+	"updated":    dateField,
+	"sort":       sortField,
+	"order":      orderField,
+	"limit":      limitField,
+}
+
+var queryFieldAliases = map[string]string{
+	"issuetype": "type",
+	"creator":   "author",
+	"startdate": "start",
+	"duedate":   "due",
+	"count":     "limit",
+}
+
+// querySortKeys maps the lower-cased sort keys and their aliases to the sort keys of the API.
+var querySortKeys = map[string]string{
+	"issuetype":      "issueType",
+	"type":           "issueType",
+	"category":       "category",
+	"version":        "version",
+	"milestone":      "milestone",
+	"summary":        "summary",
+	"status":         "status",
+	"priority":       "priority",
+	"attachment":     "attachment",
+	"sharedfile":     "sharedFile",
+	"created":        "created",
+	"createduser":    "createdUser",
+	"author":         "createdUser",
+	"updated":        "updated",
+	"updateduser":    "updatedUser",
+	"assignee":       "assignee",
+	"startdate":      "startDate",
+	"start":          "startDate",
+	"duedate":        "dueDate",
+	"due":            "dueDate",
+	"estimatedhours": "estimatedHours",
+	"actualhours":    "actualHours",
+	"childissue":     "childIssue",
+}
+
+const queryDateLayout = "2006-01-02"
+
+// ParseIssueQuery parses an issue query.
+func ParseIssueQuery(query string) (*IssueQuery, error) {
+	p := &queryParser{s: query}
+	q := &IssueQuery{}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.s) {
+			return q, nil
+		}
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		q.Terms = append(q.Terms, term)
+	}
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) errorf(pos int, format string, args ...interface{}) error {
+	return &QueryError{Query: p.s, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.s) && isQuerySpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) atTermEnd() bool {
+	return p.pos >= len(p.s) || isQuerySpace(p.s[p.pos])
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func (p *queryParser) term() (QueryTerm, error) {
+	start := p.pos
+	if p.peek() == '"' {
+		v, err := p.quoted()
+		if err != nil {
+			return QueryTerm{}, err
+		}
+		if !p.atTermEnd() {
+			return QueryTerm{}, p.errorf(p.pos, "unexpected %q after quoted keyword", p.s[p.pos])
+		}
+		return QueryTerm{Values: []string{v}}, nil
+	}
+
+	for p.pos < len(p.s) && isQueryIdent(p.s[p.pos]) {
+		p.pos++
+	}
+	if name := p.s[start:p.pos]; len(name) > 0 {
+		if op := p.op(); len(op) > 0 {
+			return p.fieldTerm(start, name, op)
+		}
+	}
+
+	p.pos = start
+	for !p.atTermEnd() {
+		p.pos++
+	}
+	return QueryTerm{Values: []string{p.s[start:p.pos]}}, nil
+}
+
+func isQueryIdent(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+func (p *queryParser) op() string {
+	for _, op := range []string{"<=", ">=", "<", ">", ":"} {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *queryParser) quoted() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.s):
+			b.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf(start, "unterminated quoted string")
+}
+
+func (p *queryParser) fieldTerm(start int, name, op string) (QueryTerm, error) {
+	field := strings.ToLower(name)
+	if alias, ok := queryFieldAliases[field]; ok {
+		field = alias
+	}
+	kind, ok := queryFields[field]
+	if !ok {
+		if suggestion := suggestQueryField(field); len(suggestion) > 0 {
+			return QueryTerm{}, p.errorf(start, "unknown field %q (did you mean %q?)", name, suggestion)
+		}
+		return QueryTerm{}, p.errorf(start, "unknown field %q", name)
+	}
+	opPos := p.pos - len(op)
+	if op != ":" && kind != dateField {
+		return QueryTerm{}, p.errorf(opPos, "field %q does not support %q; use %s:value", field, op, field)
+	}
+
+	term := QueryTerm{Field: field, Op: op}
+	valuesPos := p.pos
+	for {
+		valuePos := p.pos
+		var v string
+		if p.peek() == '"' {
+			var err error
+			if v, err = p.quoted(); err != nil {
+				return QueryTerm{}, err
+			}
+		} else {
+			for !p.atTermEnd() && p.s[p.pos] != ',' {
+				if p.s[p.pos] == '"' {
+					return QueryTerm{}, p.errorf(p.pos, "unexpected quote in value of %q; quote the whole value", field)
+				}
+				p.pos++
+			}
+			v = p.s[valuePos:p.pos]
+			if len(v) == 0 {
+				return QueryTerm{}, p.errorf(valuePos, "missing value for field %q", field)
+			}
+		}
+		term.Values = append(term.Values, v)
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	if !p.atTermEnd() {
+		return QueryTerm{}, p.errorf(p.pos, "unexpected %q after value of %q", p.s[p.pos], field)
+	}
+
+	if kind != listField && len(term.Values) > 1 {
+		return QueryTerm{}, p.errorf(valuesPos, "field %q takes a single value", field)
+	}
+	v := term.Values[0]
+	switch kind {
+	case dateField:
+		if _, err := time.Parse(queryDateLayout, v); err != nil {
+			return QueryTerm{}, p.errorf(valuesPos, "invalid date %q for %q; use yyyy-MM-dd", v, field)
+		}
+	case sortField:
+		if _, ok := querySortKeys[strings.ToLower(v)]; !ok {
+			return QueryTerm{}, p.errorf(valuesPos, "unknown sort key %q", v)
+		}
+	case orderField:
+		if v != "asc" && v != "desc" {
+			return QueryTerm{}, p.errorf(valuesPos, "order must be asc or desc, not %q", v)
+		}
+	case limitField:
+		if n, err := strconv.Atoi(v); err != nil || n < 1 || n > maxSearchCount {
+			return QueryTerm{}, p.errorf(valuesPos, "limit must be a number from 1 to %d, not %q", maxSearchCount, v)
+		}
+	}
+	return term, nil
+}
+
+// suggestQueryField returns the known field closest to name, or "" if none is close.
+func suggestQueryField(name string) string {
+	best, bestDistance := "", 3
+	for field := range queryFields {
+		if d := levenshtein(name, field); d < bestDistance || d == bestDistance && field < best {
+			best, bestDistance = field, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// String returns the query in the syntax accepted by ParseIssueQuery.
+func (q *IssueQuery) String() string {
+	terms := make([]string, 0, len(q.Terms))
+	for _, t := range q.Terms {
+		terms = append(terms, t.String())
+	}
+	return strings.Join(terms, " ")
+}
+
+// String returns the term in the syntax accepted by ParseIssueQuery.
+func (t QueryTerm) String() string {
+	if len(t.Field) == 0 {
+		return quoteQueryValue(strings.Join(t.Values, " "), true)
+	}
+	values := make([]string, 0, len(t.Values))
+	for _, v := range t.Values {
+		values = append(values, quoteQueryValue(v, false))
+	}
+	return t.Field + t.Op + strings.Join(values, ",")
+}
+
+func quoteQueryValue(v string, keyword bool) string {
+	special := " \t\r\n,\"\\"
+	if keyword {
+		special += ":<>"
+	}
+	if len(v) > 0 && !strings.ContainsAny(v, special) {
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
+
+// Compile compiles the query into an IssueSearchRequest, resolving the names
+// with r. The fields type, category, milestone, version, assignee and author
+// require a project, and the names are looked up in all the projects of the query.
+func (q *IssueQuery) Compile(r *Resolver) (IssueSearchRequest, error) {
+	request := IssueSearchRequest{}
+
+	var projectKeys []string
+	for _, t := range q.Terms {
+		if t.Field == "project" {
+			projectKeys = append(projectKeys, t.Values...)
+		}
+	}
+
+	var keywords []string
+	for _, t := range q.Terms {
+		if len(t.Field) == 0 {
+			keywords = append(keywords, t.Values...)
+			continue
+		}
+
+		var err error
+		switch t.Field {
+		case "project":
+			request.ProjectIDs, err = resolveQueryValues(request.ProjectIDs, t.Values, r.ProjectID)
+		case "status":
+			request.StatusIDs, err = resolveQueryValues(request.StatusIDs, t.Values, r.StatusID)
+		case "priority":
+			request.PriorityIDs, err = resolveQueryValues(request.PriorityIDs, t.Values, r.PriorityID)
+		case "resolution":
+			request.ResolutionIDs, err = resolveQueryValues(request.ResolutionIDs, t.Values, r.ResolutionID)
+		case "type":
+			request.IssueTypeIDs, err = resolveQueryValues(request.IssueTypeIDs, t.Values, inProjects(t.Field, projectKeys, r.IssueTypeID))
+		case "category":
+			request.CategoryIDs, err = resolveQueryValues(request.CategoryIDs, t.Values, inProjects(t.Field, projectKeys, r.CategoryID))
+		case "milestone":
+			request.MilestoneIDs, err = resolveQueryValues(request.MilestoneIDs, t.Values, inProjects(t.Field, projectKeys, r.VersionID))
+		case "version":
+			request.VersionIDs, err = resolveQueryValues(request.VersionIDs, t.Values, inProjects(t.Field, projectKeys, r.VersionID))
+		case "assignee":
+			request.AssigneeIDs, err = resolveQueryValues(request.AssigneeIDs, t.Values, userInProjects(r, t.Field, projectKeys))
+		case "author":
+			request.CreatedUserIDs, err = resolveQueryValues(request.CreatedUserIDs, t.Values, userInProjects(r, t.Field, projectKeys))
+		case "start":
+			compileDateTerm(t, &request.StartDateSince, &request.StartDateUntil)
+		case "due":
+			compileDateTerm(t, &request.DueDateSince, &request.DueDateUntil)
+		case "created":
+			compileDateTerm(t, &request.CreatedSince, &request.CreatedUntil)
+		case "updated":
+			compileDateTerm(t, &request.UpdatedSince, &request.UpdatedUntil)
+		case "sort":
+			key := querySortKeys[strings.ToLower(t.Values[0])]
+			request.Sort = &key
+		case "order":
+			order := t.Values[0]
+			request.Order = &order
+		case "limit":
+			count, _ := strconv.Atoi(t.Values[0])
+			request.Count = &count
+		default:
+			err = fmt.Errorf("unknown field")
+		}
+		if err != nil {
+			return IssueSearchRequest{}, fmt.Errorf("%v: %w", t, err)
+		}
+	}
+
+	if len(keywords) > 0 {
+		keyword := strings.Join(keywords, " ")
+		request.Keyword = &keyword
+	}
+	return request, nil
+}
+
+func resolveQueryValues(ids []int, values []string, resolve func(string) (int, error)) ([]int, error) {
+	for _, v := range values {
+		id, err := resolve(v)
+		if err != nil {
+			return nil, err
+		}
+		if !containsInt(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func containsInt(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// inProjects returns a function which resolves a name in the first project
+// which knows it.
+func inProjects(field string, projectKeys []string, resolve func(projectKey, name string) (int, error)) func(string) (int, error) {
+	return func(name string) (int, error) {
+		if len(projectKeys) == 0 {
+			return 0, fmt.Errorf("%s requires a project:KEY term", field)
+		}
+		var firstErr error
+		for _, key := range projectKeys {
+			id, err := resolve(key, name)
+			if err == nil {
+				return id, nil
+			}
+			if e, ok := err.(*ResolveError); !ok || e.Ambiguous {
+				return 0, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return 0, firstErr
+	}
+}
+
+func userInProjects(r *Resolver, field string, projectKeys []string) func(string) (int, error) {
+	resolve := inProjects(field, projectKeys, r.UserID)
+	return func(name string) (int, error) {
+		if name == "me" {
+			return r.MyselfID()
+		}
+		return resolve(name)
+	}
+}
+
+// compileDateTerm narrows the since and until dates by the term.
+func compileDateTerm(t QueryTerm, since, until **string) {
+	date, _ := time.Parse(queryDateLayout, t.Values[0])
+	narrowSince := func(d time.Time) {
+		s := d.Format(queryDateLayout)
+		if *since == nil || **since < s {
+			*since = &s
+		}
+	}
+	narrowUntil := func(d time.Time) {
+		s := d.Format(queryDateLayout)
+		if *until == nil || **until > s {
+			*until = &s
+		}
+	}
+
+	switch t.Op {
+	case ":":
+		narrowSince(date)
+		narrowUntil(date)
+	case "<":
+		narrowUntil(date.AddDate(0, 0, -1))
+	case "<=":
+		narrowUntil(date)
+	case ">":
+		narrowSince(date.AddDate(0, 0, 1))
+	case ">=":
+		narrowSince(date)
+	}
+}
+
+// Query searches issues with an issue query. See IssueQuery for the syntax.
+func (s *IssuesService) Query(query string, r *Resolver) ([]*Issue, *Response, error) {
+	q, err := ParseIssueQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := q.Compile(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.Search(request)
+}
@@ -0,0 +1,124 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueQuery(t *testing.T) {
+	q, err := ParseIssueQuery(`project:WEB status:open,"in progress" assignee:me milestone:"v2.1" due<2026-11-01 sort:updated login "sso error"`)
+	if err != nil {
+		t.Fatalf("ParseIssueQuery returned error: %v", err)
+	}
+
+	want := []QueryTerm{
+		{Field: "project", Op: ":", Values: []string{"WEB"}},
+		{Field: "status", Op: ":", Values: []string{"open", "in progress"}},
+		{Field: "assignee", Op: ":", Values: []string{"me"}},
+		{Field: "milestone", Op: ":", Values: []string{"v2.1"}},
+		{Field: "due", Op: "<", Values: []string{"2026-11-01"}},
+		{Field: "sort", Op: ":", Values: []string{"updated"}},
+		{Values: []string{"login"}},
+		{Values: []string{"sso error"}},
+	}
+	if !reflect.DeepEqual(q.Terms, want) {
+		t.Errorf("ParseIssueQuery returned %+v, want %+v", q.Terms, want)
+	}
+}
+
+func TestIssueQuery_String(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`project:WEB  status:open,"in progress"`, `project:WEB status:open,"in progress"`},
+		{`Type:"Bug" due>=2026-10-01 due<=2026-10-31`, `type:Bug due>=2026-10-01 due<=2026-10-31`},
+		{`"status:open" "say \"hi\"" plain`, `"status:open" "say \"hi\"" plain`},
+		{`milestone:"a,b" category:"back\\slash"`, `milestone:"a,b" category:"back\\slash"`},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseIssueQuery(tt.in)
+		if err != nil {
+			t.Errorf("ParseIssueQuery(%v) returned error: %v", tt.in, err)
+			continue
+		}
+		got := q.String()
+		if got != tt.want {
+			t.Errorf("ParseIssueQuery(%v).String() returned %v, want %v", tt.in, got, tt.want)
+		}
+		again, err := ParseIssueQuery(got)
+		if err != nil || !reflect.DeepEqual(again, q) {
+			t.Errorf("ParseIssueQuery(%v) does not round-trip: %+v, %v", got, again, err)
+		}
+	}
+}
+
+func TestParseIssueQuery_errors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`asignee:me`, `column 1: unknown field "asignee" (did you mean "assignee"?)`},
+		{`project:WEB status<open`, `column 19: field "status" does not support "<"`},
+		{`due<2026/11/01`, `column 5: invalid date "2026/11/01" for "due"; use yyyy-MM-dd`},
+		{`status:open,`, `column 13: missing value for field "status"`},
+		{`milestone:"v2.1`, `column 11: unterminated quoted string`},
+		{`sort:foo`, `column 6: unknown sort key "foo"`},
+		{`limit:500`, `column 7: limit must be a number from 1 to 100`},
+		{`order:asc,desc`, `column 7: field "order" takes a single value`},
+		{`status:"open"x`, `column 14: unexpected 'x' after value of "status"`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseIssueQuery(tt.in)
+		if _, ok := err.(*QueryError); !ok {
+			t.Errorf("ParseIssueQuery(%v) returned %v, want *QueryError", tt.in, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseIssueQuery(%v) returned %q, want %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestIssueQuery_Compile(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	setupMetadata(mux)
+	mux.HandleFunc("/api/v2/users/myself", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"userId":"bob","name":"Bob"}`)
+	})
+	resolver := NewResolver(client, 0)
+
+	q, err := ParseIssueQuery(`project:WEB status:open,"in progress" assignee:me milestone:"v2.1" due<2026-11-01 due>=2026-10-01 sort:due order:asc limit:50 login`)
+	if err != nil {
+		t.Fatalf("ParseIssueQuery returned error: %v", err)
+	}
+	request, err := q.Compile(resolver)
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+
+	got, _ := addOptions("issues", request)
+	want := "issues?assigneeId%5B%5D=2&count=50&dueDateSince=2026-10-01&dueDateUntil=2026-10-31&keyword=login" +
+		"&milestoneId%5B%5D=32&order=asc&projectId%5B%5D=1&sort=dueDate&statusId%5B%5D=1&statusId%5B%5D=2"
+	if got != want {
+		t.Errorf("Compile returned %v, want %v", got, want)
+	}
+
+	q, _ = ParseIssueQuery(`milestone:v2.1`)
+	if _, err := q.Compile(resolver); err == nil || !strings.Contains(err.Error(), "requires a project") {
+		t.Errorf("Compile without project returned %v", err)
+	}
+
+	q, _ = ParseIssueQuery(`project:WEB type:Story`)
+	_, err = q.Compile(resolver)
+	if e, ok := err.(interface{ Unwrap() error }); !ok {
+		t.Errorf("Compile with unknown type returned %v", err)
+	} else if _, ok := e.Unwrap().(*ResolveError); !ok {
+		t.Errorf("Compile with unknown type returned %v, want to wrap *ResolveError", err)
+	}
+}
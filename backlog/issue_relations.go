@@ -0,0 +1,138 @@
+package backlog
+
+import (
+	"strconv"
+)
+
+// maxSearchCount is the maximum count of issues in a page of IssuesService.Search.
+const maxSearchCount = 100
+
+// IssueNode is an issue with its child issues.
+type IssueNode struct {
+	Issue    *Issue
+	Children []*IssueNode
+}
+
+// ListParticipants lists the users who participate in the issue.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-issue-participant-list/
+func (s *IssuesService) ListParticipants(issueKey string) ([]*User, *Response, error) {
+	u := "issues/" + issueKey + "/participants"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := []*User{}
+	resp, err := s.client.Do(req, &users)
+	if err != nil {
+		return nil, resp, err
+	}
+	return users, resp, nil
+}
+
+// SearchAll searches issues and follows the pages until all the matching issues are fetched.
+// request.Offset is the offset of the first page, and request.Count is the size of the pages,
+// which is limited to 1..100.
+func (s *IssuesService) SearchAll(request IssueSearchRequest) ([]*Issue, *Response, error) {
+	count := maxSearchCount
+	if request.Count != nil {
+		count = *request.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	if count > maxSearchCount {
+		count = maxSearchCount
+	}
+	offset := 0
+	if request.Offset != nil {
+		offset = *request.Offset
+	}
+
+	all := []*Issue{}
+	for {
+		page, pageOffset := count, offset
+		request.Count, request.Offset = &page, &pageOffset
+		issues, resp, err := s.Search(request)
+		if err != nil {
+			return nil, resp, err
+		}
+		all = append(all, issues...)
+		if len(issues) < count || len(issues) == 0 {
+			return all, resp, nil
+		}
+		offset += len(issues)
+	}
+}
+
+// ListChildren lists all the child issues of the parent issue.
+func (s *IssuesService) ListChildren(parentKey string) ([]*Issue, *Response, error) {
+	parent, resp, err := s.Get(parentKey)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sort, order := "created", "asc"
+	return s.SearchAll(IssueSearchRequest{
+		ProjectIDs:     []int{parent.ProjectID},
+		ParentIssueIDs: []int{parent.ID},
+		Sort:           &sort,
+		Order:          &order,
+	})
+}
+
+// GetParent gets the parent issue of the issue.
+// It returns a nil Issue if the issue is not a child issue.
+func (s *IssuesService) GetParent(issueKey string) (*Issue, *Response, error) {
+	issue, resp, err := s.Get(issueKey)
+	if err != nil {
+		return nil, resp, err
+	}
+	if issue.ParentIssueID == nil {
+		return nil, resp, nil
+	}
+	return s.Get(strconv.Itoa(*issue.ParentIssueID))
+}
+
+// Tree lists all issues in the project as trees of parent and child issues.
+// The roots are the issues without parents, and the children are ordered by creation.
+func (s *IssuesService) Tree(projectKey string) ([]*IssueNode, *Response, error) {
+	project, resp, err := s.client.Projects.Get(projectKey)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sort, order := "created", "asc"
+	issues, resp, err := s.SearchAll(IssueSearchRequest{
+		ProjectIDs: []int{project.ID},
+		Sort:       &sort,
+		Order:      &order,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	return buildIssueTree(issues), resp, nil
+}
+
+// buildIssueTree builds trees of issues. The issues whose parent is not in
+// issues are treated as roots.
+func buildIssueTree(issues []*Issue) []*IssueNode {
+	nodes := make(map[int]*IssueNode, len(issues))
+	for _, issue := range issues {
+		nodes[issue.ID] = &IssueNode{Issue: issue}
+	}
+
+	roots := []*IssueNode{}
+	for _, issue := range issues {
+		node := nodes[issue.ID]
+		if issue.ParentIssueID != nil {
+			if parent, ok := nodes[*issue.ParentIssueID]; ok && parent != node {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
@@ -0,0 +1,131 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestIssuesService_ListChildren(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/TEST-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":10,"projectId":1,"issueKey":"TEST-1"}`)
+	})
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("parentIssueId[]"); got != "10" {
+			t.Errorf("parentIssueId[]: %v, want 10", got)
+		}
+		if got := q.Get("count"); got != "100" {
+			t.Errorf("count: %v, want 100", got)
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		// 150 children: a full page and a half page.
+		n := 150 - offset
+		if n > 100 {
+			n = 100
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d,"parentIssueId":10}`, 100+offset+i)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	children, _, err := client.Issues.ListChildren("TEST-1")
+	if err != nil {
+		t.Fatalf("Issues.ListChildren returned error: %v", err)
+	}
+	if len(children) != 150 {
+		t.Fatalf("Issues.ListChildren returned %d issues, want 150", len(children))
+	}
+	if children[0].ID != 100 || children[149].ID != 249 {
+		t.Errorf("Issues.ListChildren returned issues %d..%d, want 100..249", children[0].ID, children[149].ID)
+	}
+}
+
+func TestIssuesService_SearchAll_count(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	const matches = 250
+	var counts []string
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		counts = append(counts, q.Get("count"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		count, _ := strconv.Atoi(q.Get("count"))
+
+		// Backlog returns at most 100 issues whatever count is.
+		n := matches - offset
+		if n > count {
+			n = count
+		}
+		if n > 100 {
+			n = 100
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d}`, offset+i)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	tests := []struct {
+		count      int
+		wantCounts string
+		wantPages  int
+	}{
+		{150, "100", 3},
+		{100, "100", 3},
+		{0, "1", matches + 1},
+		{-5, "1", matches + 1},
+	}
+	for _, tt := range tests {
+		counts = nil
+		count := tt.count
+		issues, _, err := client.Issues.SearchAll(IssueSearchRequest{Count: &count})
+		if err != nil {
+			t.Fatalf("Issues.SearchAll(count=%d) returned error: %v", tt.count, err)
+		}
+		if len(issues) != matches || issues[matches-1].ID != matches-1 {
+			t.Errorf("Issues.SearchAll(count=%d) returned %d issues, want %d", tt.count, len(issues), matches)
+		}
+		if len(counts) != tt.wantPages || counts[0] != tt.wantCounts {
+			t.Errorf("Issues.SearchAll(count=%d) requested %d pages of count %v, want %d pages of count %v",
+				tt.count, len(counts), counts[0], tt.wantPages, tt.wantCounts)
+		}
+	}
+}
+
+func TestBuildIssueTree(t *testing.T) {
+	id := func(i int) *int { return &i }
+	issues := []*Issue{
+		{ID: 1},
+		{ID: 2, ParentIssueID: id(1)},
+		{ID: 3},
+		{ID: 4, ParentIssueID: id(1)},
+		{ID: 5, ParentIssueID: id(99)}, // parent is not in the list
+	}
+
+	roots := buildIssueTree(issues)
+	if len(roots) != 3 {
+		t.Fatalf("buildIssueTree returned %d roots, want 3", len(roots))
+	}
+	if roots[0].Issue.ID != 1 || len(roots[0].Children) != 2 || roots[0].Children[1].Issue.ID != 4 {
+		t.Errorf("buildIssueTree returned root %+v", roots[0])
+	}
+	if roots[1].Issue.ID != 3 || roots[2].Issue.ID != 5 {
+		t.Errorf("buildIssueTree returned roots %v, %v", roots[1].Issue.ID, roots[2].Issue.ID)
+	}
+}
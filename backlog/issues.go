@@ -24,6 +24,8 @@ type Issue struct {
 	StartDate   time.Time `json:"startDate"`
 	DueDate     time.Time `json:"dueDate"`
 
+	ParentIssueID *int `json:"parentIssueId"`
+
 	Status struct {
 		ID   int    `json:"id"`
 		Name string `json:"name"`
@@ -40,12 +42,16 @@ type Issue struct {
 		Name   string `json:"name"`
 	} `json:"createdUser"`
 
+	Resolution *Resolution `json:"resolution"` // 完了理由 (未設定の場合は nil)
+
 	IssueType  IssueType  `json:"issueType"`
 	Categories []Category `json:"category"`
 
 	// Backlog の仕様では Version と　Milestone は同じ型になる
 	Versions   []Version `json:"versions"`
 	Milestones []Version `json:"milestone"`
+
+	Stars []Star `json:"stars"`
 }
 
 // IssueRequest represents a request to create/edit an issue.
@@ -53,6 +59,7 @@ type IssueRequest struct {
 	Summary       *string
 	Description   *string
 	StatusID      *int
+	ResolutionID  *int
 	ProjectID     *int
 	PriorityID    *int
 	CategoryID    *int
@@ -63,29 +70,38 @@ type IssueRequest struct {
 	MilestoneID   *int
 	StartDate     *string
 	DueDate       *string
+	Comment       *string // 課題の更新時に追加するコメント
 }
 
 // IssueSearchRequest represents a request to create/edit an issue.
 // https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-issue-list/
 type IssueSearchRequest struct {
-	IDs            []int   `url:"id[],omitempty"`             // 課題のID
-	ProjectIDs     []int   `url:"projectId[],omitempty"`      // プロジェクトのID
-	StatusIDs      []int   `url:"statusId[],omitempty"`       // 状態のID
-	PriorityIDs    []int   `url:"priorityId[],omitempty"`     // 優先度のID
-	CategoryIDs    []int   `url:"categoryId[],omitempty"`     // カテゴリーのID
-	VersionIDs     []int   `url:"versionId[],omitempty"`      // 課題の発生バージョンのID
-	MilestoneIDs   []int   `url:"milestoneId[],omitempty"`    // 課題のマイルストーンのID
-	IssueTypeIDs   []int   `url:"issueTypeId[],omitempty"`    // 種別のID
-	AssigneeIDs    []int   `url:"assigneeId[],omitempty"`     // 担当者のID
-	ParentIssueIDs []int   `url:"parentIssueId[],omitempty"`  // 親課題のID
-	StartDateSince *string `url:"startDateSince[],omitempty"` // 開始日 (yyyy-MM-dd)
-	DueDateSince   *string `url:"dueDateSince,omitempty"`     // 期限日 (yyyy-MM-dd)
-	ParentChild    *int    `url:"parentChild,omitempty"`      // 親子課題の条件
-	Sort           *string `url:"sort,omitempty"`             // 課題一覧のソートに使用する属性名
-	Order          *string `url:"order,omitempty"`            // `asc` または `desc` 指定が無い場合は `desc`
-	Keyword        *string `url:"keyword,omitempty"`          // 検索キーワード
-	Count          *int    `url:"count,omitempty"`            // 取得上限 (1-100) 指定が無い場合は 20
-	offset         *int    `url:"offset,omitempty"`
+	IDs            []int   `url:"id[],omitempty"`            // 課題のID
+	ProjectIDs     []int   `url:"projectId[],omitempty"`     // プロジェクトのID
+	StatusIDs      []int   `url:"statusId[],omitempty"`      // 状態のID
+	PriorityIDs    []int   `url:"priorityId[],omitempty"`    // 優先度のID
+	CategoryIDs    []int   `url:"categoryId[],omitempty"`    // カテゴリーのID
+	VersionIDs     []int   `url:"versionId[],omitempty"`     // 課題の発生バージョンのID
+	MilestoneIDs   []int   `url:"milestoneId[],omitempty"`   // 課題のマイルストーンのID
+	IssueTypeIDs   []int   `url:"issueTypeId[],omitempty"`   // 種別のID
+	AssigneeIDs    []int   `url:"assigneeId[],omitempty"`    // 担当者のID
+	CreatedUserIDs []int   `url:"createdUserId[],omitempty"` // 登録者のID
+	ResolutionIDs  []int   `url:"resolutionId[],omitempty"`  // 完了理由のID
+	ParentIssueIDs []int   `url:"parentIssueId[],omitempty"` // 親課題のID
+	StartDateSince *string `url:"startDateSince,omitempty"`  // 開始日 (yyyy-MM-dd)
+	StartDateUntil *string `url:"startDateUntil,omitempty"`  // 開始日 (yyyy-MM-dd)
+	DueDateSince   *string `url:"dueDateSince,omitempty"`    // 期限日 (yyyy-MM-dd)
+	DueDateUntil   *string `url:"dueDateUntil,omitempty"`    // 期限日 (yyyy-MM-dd)
+	CreatedSince   *string `url:"createdSince,omitempty"`    // 登録日 (yyyy-MM-dd)
+	CreatedUntil   *string `url:"createdUntil,omitempty"`    // 登録日 (yyyy-MM-dd)
+	UpdatedSince   *string `url:"updatedSince,omitempty"`    // 更新日 (yyyy-MM-dd)
+	UpdatedUntil   *string `url:"updatedUntil,omitempty"`    // 更新日 (yyyy-MM-dd)
+	ParentChild    *int    `url:"parentChild,omitempty"`     // 親子課題の条件
+	Sort           *string `url:"sort,omitempty"`            // 課題一覧のソートに使用する属性名
+	Order          *string `url:"order,omitempty"`           // `asc` または `desc` 指定が無い場合は `desc`
+	Keyword        *string `url:"keyword,omitempty"`         // 検索キーワード
+	Count          *int    `url:"count,omitempty"`           // 取得上限 (1-100) 指定が無い場合は 20
+	Offset         *int    `url:"offset,omitempty"`          // オフセット
 }
 
 // Get an issue.
@@ -180,6 +196,9 @@ func (r IssueRequest) makeValues() url.Values {
 	if r.StatusID != nil {
 		v.Set("statusId", fmt.Sprintf("%d", *r.StatusID))
 	}
+	if r.ResolutionID != nil {
+		v.Set("resolutionId", fmt.Sprintf("%d", *r.ResolutionID))
+	}
 	if r.PriorityID != nil {
 		v.Set("priorityId", fmt.Sprintf("%d", *r.PriorityID))
 	}
@@ -210,6 +229,9 @@ func (r IssueRequest) makeValues() url.Values {
 	if r.DueDate != nil {
 		v.Set("dueDate", *r.DueDate)
 	}
+	if r.Comment != nil {
+		v.Set("comment", *r.Comment)
+	}
 
 	return v
 }
@@ -0,0 +1,115 @@
+package backlog
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the maximum length of the bodies in the logs.
+const maxLoggedBody = 4096
+
+// logResponse logs a request at debug level if it succeeded, at warn level if
+// Backlog returned a client error, and at error level otherwise.
+func (c *Client) logResponse(req *http.Request, resp *http.Response, body []byte, duration time.Duration, err error) {
+	if c.Logger == nil {
+		return
+	}
+
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactURL(req.URL)),
+		slog.Duration("duration", duration),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if n, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+			attrs = append(attrs, slog.Int("rate_limit_remaining", n))
+		}
+	}
+	if err != nil {
+		level = slog.LevelError
+		if resp != nil && 400 <= resp.StatusCode && resp.StatusCode < 500 {
+			level = slog.LevelWarn
+		}
+		if e, ok := err.(*ErrorResponse); ok {
+			codes := make([]int, 0, len(e.Errors))
+			messages := make([]string, 0, len(e.Errors))
+			for _, e := range e.Errors {
+				codes = append(codes, e.Code)
+				messages = append(messages, e.Message)
+			}
+			attrs = append(attrs, slog.Any("error_codes", codes), slog.Any("error_messages", messages))
+		} else {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+	}
+	if c.LogBodies {
+		if req.GetBody != nil {
+			if r, err := req.GetBody(); err == nil {
+				var b strings.Builder
+				_, err := io.Copy(&b, r)
+				r.Close()
+				if err == nil && b.Len() > 0 {
+					attrs = append(attrs, slog.String("request_body", redactBody(b.String())))
+				}
+			}
+		}
+		if len(body) > 0 {
+			attrs = append(attrs, slog.String("response_body", redactBody(string(body))))
+		}
+	}
+
+	c.Logger.LogAttrs(req.Context(), level, "backlog: request", attrs...)
+}
+
+// redactedParams are the parameters redacted in URLs and bodies of the logs.
+var redactedParams = []string{"apiKey", "access_token", "refresh_token", "client_secret"}
+
+var redactedJSONPattern = regexp.MustCompile(`"(apiKey|access_token|refresh_token|client_secret)"\s*:\s*"[^"]*"`)
+
+func redactURL(u *url.URL) string {
+	u = SanitizeURL(u)
+	q := u.Query()
+	redacted := false
+	for _, k := range redactedParams {
+		if len(q.Get(k)) > 0 {
+			q.Set(k, "REDACTED")
+			redacted = true
+		}
+	}
+	if redacted {
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// redactBody redacts the secrets in a url-encoded or JSON body, and truncates
+// it to maxLoggedBody.
+func redactBody(body string) string {
+	if strings.HasPrefix(strings.TrimSpace(body), "{") || strings.HasPrefix(strings.TrimSpace(body), "[") {
+		body = redactedJSONPattern.ReplaceAllString(body, `"$1":"REDACTED"`)
+	} else {
+		form, _ := url.ParseQuery(body) // keep the pairs parsed before an error
+		redacted := false
+		for _, k := range redactedParams {
+			if _, ok := form[k]; ok {
+				form.Set(k, "REDACTED")
+				redacted = true
+			}
+		}
+		if redacted {
+			body = form.Encode()
+		}
+	}
+	if len(body) > maxLoggedBody {
+		body = body[:maxLoggedBody] + "...(truncated)"
+	}
+	return body
+}
@@ -0,0 +1,76 @@
+package backlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Logger(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "149")
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"id":1,"issueKey":"WEB-1"}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"message":"Please input summary.","code":7,"moreInfo":""}]}`)
+	})
+
+	var buf bytes.Buffer
+	client.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client.LogBodies = true
+
+	client.Issues.Get("WEB-1")
+	summary := ""
+	client.Issues.Edit("WEB-1", IssueRequest{Summary: &summary})
+
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("logs contain the API key:\n%s", buf.String())
+	}
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(records))
+	}
+
+	get, edit := records[0], records[1]
+	if get["level"] != "DEBUG" || get["method"] != "GET" || get["status"] != 200.0 || get["rate_limit_remaining"] != 149.0 ||
+		!strings.Contains(get["url"].(string), "apiKey=REDACTED") || get["response_body"] != `{"id":1,"issueKey":"WEB-1"}` {
+		t.Errorf("logged %v for GET", get)
+	}
+	if edit["level"] != "WARN" || edit["status"] != 400.0 || fmt.Sprint(edit["error_codes"]) != "[7]" || edit["request_body"] != "summary=" {
+		t.Errorf("logged %v for PATCH", edit)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"summary=a", "summary=a"},
+		{"apiKey=secret&summary=a", "apiKey=REDACTED&summary=a"},
+		{"refresh_token=secret&grant_type=refresh_token", "grant_type=refresh_token&refresh_token=REDACTED"},
+		{`{"access_token": "secret", "expires_in": 3600}`, `{"access_token":"REDACTED", "expires_in": 3600}`},
+		{strings.Repeat("a", maxLoggedBody+1), strings.Repeat("a", maxLoggedBody) + "...(truncated)"},
+	}
+	for _, tt := range tests {
+		if got := redactBody(tt.in); got != tt.want {
+			t.Errorf("redactBody(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
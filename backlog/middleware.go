@@ -0,0 +1,138 @@
+package backlog
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Doer sends an API request and decodes the response into v, like Client.Do.
+type Doer interface {
+	Do(req *http.Request, v interface{}) (*Response, error)
+}
+
+// DoerFunc is an adapter to use a function as a Doer.
+type DoerFunc func(req *http.Request, v interface{}) (*Response, error)
+
+// Do calls f(req, v).
+func (f DoerFunc) Do(req *http.Request, v interface{}) (*Response, error) {
+	return f(req, v)
+}
+
+// Middleware wraps a Doer to inspect or modify the requests, the decoded
+// responses and the errors.
+//
+//	client.Use(func(next backlog.Doer) backlog.Doer {
+//		return backlog.DoerFunc(func(req *http.Request, v interface{}) (*backlog.Response, error) {
+//			// before the request
+//			resp, err := next.Do(req, v)
+//			// after the response is decoded into v
+//			return resp, err
+//		})
+//	})
+type Middleware func(next Doer) Doer
+
+// Use adds the middlewares to the client. The middleware added first is the
+// outermost, which sees the request first and the response last.
+// Use must not be called concurrently with requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// isMutating reports whether the request changes data in Backlog.
+func isMutating(req *http.Request) bool {
+	return req.Method != "GET" && req.Method != "HEAD"
+}
+
+// MutatingOnly applies m only to the requests which change data, i.e. other
+// than GET and HEAD, e.g. to audit-log them.
+func MutatingOnly(m Middleware) Middleware {
+	return func(next Doer) Doer {
+		wrapped := m(next)
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			if isMutating(req) {
+				return wrapped.Do(req, v)
+			}
+			return next.Do(req, v)
+		})
+	}
+}
+
+// HeaderMiddleware adds the headers to every request.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			for k, values := range header {
+				req.Header.Del(k)
+				for _, value := range values {
+					req.Header.Add(k, value)
+				}
+			}
+			return next.Do(req, v)
+		})
+	}
+}
+
+// LoggingMiddleware logs the method, the URL without the API key, the status
+// code, the duration and the error of every request to logger.
+// If logger is nil, the standard logger is used.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req, v)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			if err != nil {
+				logger.Printf("backlog: %s %s: %d (%v): %v", req.Method, SanitizeURL(req.URL), status, time.Since(start), err)
+			} else {
+				logger.Printf("backlog: %s %s: %d (%v)", req.Method, SanitizeURL(req.URL), status, time.Since(start))
+			}
+			return resp, err
+		})
+	}
+}
+
+// RequestMetrics is a finished request reported by MetricsMiddleware.
+type RequestMetrics struct {
+	Method     string
+	Path       string // e.g. "/api/v2/issues/WEB-1"
+	StatusCode int    // 0 if no response was received
+	Duration   time.Duration
+	Err        error
+}
+
+// MetricsRecorder records the metrics of requests.
+// It is called concurrently if the client is used concurrently.
+type MetricsRecorder interface {
+	RecordRequest(m RequestMetrics)
+}
+
+// MetricsRecorderFunc is an adapter to use a function as a MetricsRecorder.
+type MetricsRecorderFunc func(m RequestMetrics)
+
+// RecordRequest calls f(m).
+func (f MetricsRecorderFunc) RecordRequest(m RequestMetrics) {
+	f(m)
+}
+
+// MetricsMiddleware reports every request to recorder.
+func MetricsMiddleware(recorder MetricsRecorder) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req, v)
+			m := RequestMetrics{Method: req.Method, Path: req.URL.Path, Duration: time.Since(start), Err: err}
+			if resp != nil {
+				m.StatusCode = resp.StatusCode
+			}
+			recorder.RecordRequest(m)
+			return resp, err
+		})
+	}
+}
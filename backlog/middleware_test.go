@@ -0,0 +1,97 @@
+package backlog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Use(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/WEB", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Team"); got != "web" {
+			t.Errorf("X-Team header is %q, want %q", got, "web")
+		}
+		fmt.Fprint(w, `{"id":1,"projectKey":"WEB"}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request, v interface{}) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.Do(req, v)
+				calls = append(calls, name+" after "+(*v.(**Project)).ProjectKey)
+				return resp, err
+			})
+		}
+	}
+	client.Use(trace("outer"), HeaderMiddleware(http.Header{"X-Team": {"web"}}), trace("inner"))
+
+	project, _, err := client.Projects.Get("WEB")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if project.ProjectKey != "WEB" {
+		t.Errorf("Get returned %+v", project)
+	}
+	want := []string{"outer before", "inner before", "inner after WEB", "outer after WEB"}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("middlewares called as %q, want %q", calls, want)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"No issue.","code":6,"moreInfo":""}]}`)
+	})
+	mux.HandleFunc("/api/v2/issues/WEB-1/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	var buf bytes.Buffer
+	client.Use(MutatingOnly(LoggingMiddleware(log.New(&buf, "", 0))))
+	client.Issues.Get("WEB-1")
+	client.Issues.CreateComment("WEB-1", "hello")
+
+	got := buf.String()
+	if strings.Contains(got, "GET") || !strings.Contains(got, "POST") {
+		t.Errorf("MutatingOnly logged %q, want the POST only", got)
+	}
+	if strings.Contains(got, "secret") || !strings.Contains(got, "apiKey=REDACTED") {
+		t.Errorf("LoggingMiddleware logged %q, want the API key redacted", got)
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"No issue.","code":6,"moreInfo":""}]}`)
+	})
+
+	var metrics []RequestMetrics
+	client.Use(MetricsMiddleware(MetricsRecorderFunc(func(m RequestMetrics) {
+		metrics = append(metrics, m)
+	})))
+	client.Issues.Get("WEB-1")
+
+	if len(metrics) != 1 {
+		t.Fatalf("recorded %d requests, want 1", len(metrics))
+	}
+	m := metrics[0]
+	if m.Method != "GET" || m.Path != "/api/v2/issues/WEB-1" || m.StatusCode != http.StatusNotFound || m.Err == nil {
+		t.Errorf("recorded %+v", m)
+	}
+}
@@ -0,0 +1,127 @@
+package backlog
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationsService is
+type NotificationsService service
+
+// NotificationReason is the reason why a notification was sent.
+type NotificationReason int
+
+// Notification reasons
+const (
+	NotificationReasonAssigned            NotificationReason = 1  // 担当者に設定
+	NotificationReasonCommented           NotificationReason = 2  // 課題にコメント
+	NotificationReasonIssueCreated        NotificationReason = 3  // 課題の追加
+	NotificationReasonIssueUpdated        NotificationReason = 4  // 課題の更新
+	NotificationReasonFileAdded           NotificationReason = 5  // ファイルを追加
+	NotificationReasonProjectUserAdded    NotificationReason = 6  // プロジェクトユーザーの追加
+	NotificationReasonOther               NotificationReason = 9  // その他
+	NotificationReasonPullRequestAssigned NotificationReason = 10 // プルリクエストの担当者に設定
+	NotificationReasonPullRequestComment  NotificationReason = 11 // プルリクエストにコメント
+	NotificationReasonPullRequestAdded    NotificationReason = 12 // プルリクエストの追加
+	NotificationReasonPullRequestUpdated  NotificationReason = 13 // プルリクエストの更新
+)
+
+// Notification is Backlog notification for the authenticated user
+type Notification struct {
+	ID                  int                `json:"id"`
+	AlreadyRead         bool               `json:"alreadyRead"`
+	Reason              NotificationReason `json:"reason"`
+	ResourceAlreadyRead bool               `json:"resourceAlreadyRead"`
+	Project             *Project           `json:"project"`
+	Issue               *Issue             `json:"issue"`
+	Comment             *IssueComment      `json:"comment"`
+	Sender              User               `json:"sender"`
+	Created             time.Time          `json:"created"`
+}
+
+// NotificationListRequest represents a request to list notifications.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-notification/
+type NotificationListRequest struct {
+	MinID *int    `url:"minId,omitempty"` // 最小ID
+	MaxID *int    `url:"maxId,omitempty"` // 最大ID
+	Count *int    `url:"count,omitempty"` // 取得上限 (1-100) 指定が無い場合は 20
+	Order *string `url:"order,omitempty"` // `asc` または `desc` 指定が無い場合は `desc`
+}
+
+// NotificationCountRequest represents a request to count notifications.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-notification/
+type NotificationCountRequest struct {
+	AlreadyRead         *bool `url:"alreadyRead,omitempty"`         // 既読フラグ
+	ResourceAlreadyRead *bool `url:"resourceAlreadyRead,omitempty"` // リソースの既読フラグ
+}
+
+// List lists notifications of the authenticated user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-notification/
+func (s *NotificationsService) List(request NotificationListRequest) ([]*Notification, *Response, error) {
+	u, _ := addOptions("notifications", request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notifications := []*Notification{}
+	resp, err := s.client.Do(req, &notifications)
+	if err != nil {
+		return nil, resp, err
+	}
+	return notifications, resp, nil
+}
+
+// Count returns the number of notifications.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-notification/
+func (s *NotificationsService) Count(request NotificationCountRequest) (int, *Response, error) {
+	u, _ := addOptions("notifications/count", request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count := new(countResponse)
+	resp, err := s.client.Do(req, &count)
+	if err != nil {
+		return 0, resp, err
+	}
+	return count.Count, resp, nil
+}
+
+// ResetUnreadCount resets the unread notification count and returns the new count.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/reset-unread-notification-count/
+func (s *NotificationsService) ResetUnreadCount() (int, *Response, error) {
+	u := "notifications/markAsRead"
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count := new(countResponse)
+	resp, err := s.client.Do(req, &count)
+	if err != nil {
+		return 0, resp, err
+	}
+	return count.Count, resp, nil
+}
+
+// MarkAsRead marks a notification as read.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/read-notification/
+func (s *NotificationsService) MarkAsRead(notificationID int) (*Response, error) {
+	u := fmt.Sprintf("notifications/%d/markAsRead", notificationID)
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestNotificationsService_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/notifications", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("maxId"), "100"; got != want {
+			t.Errorf("maxId: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"id":22,"alreadyRead":false,"reason":2,"resourceAlreadyRead":true,
+			"project":{"id":1,"projectKey":"TEST","name":"test"},
+			"issue":{"id":10,"issueKey":"TEST-1","summary":"first issue"},
+			"comment":{"id":7,"content":"hello"},
+			"sender":{"id":3,"userId":"admin","name":"admin"}}]`)
+	})
+
+	notifications, _, err := client.Notifications.List(NotificationListRequest{MaxID: pointers.Int(100)})
+	if err != nil {
+		t.Fatalf("Notifications.List returned error: %v", err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("Notifications.List returned %d notifications, want 1", len(notifications))
+	}
+
+	n := notifications[0]
+	if n.ID != 22 || n.Reason != NotificationReasonCommented || !n.ResourceAlreadyRead {
+		t.Errorf("Notifications.List returned %+v", n)
+	}
+	if n.Issue == nil || n.Issue.IssueKey != "TEST-1" {
+		t.Errorf("Notification.Issue is %+v, want TEST-1", n.Issue)
+	}
+	if n.Comment == nil || n.Comment.Content != "hello" {
+		t.Errorf("Notification.Comment is %+v, want hello", n.Comment)
+	}
+}
+
+func TestNotificationsService_Count(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/notifications/count", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("alreadyRead"), "false"; got != want {
+			t.Errorf("alreadyRead: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"count":138}`)
+	})
+
+	count, _, err := client.Notifications.Count(NotificationCountRequest{AlreadyRead: pointers.Bool(false)})
+	if err != nil {
+		t.Fatalf("Notifications.Count returned error: %v", err)
+	}
+	if count != 138 {
+		t.Errorf("Notifications.Count returned %d, want 138", count)
+	}
+}
@@ -0,0 +1,25 @@
+module github.com/mnkd/go-backlog/backlog/otelbacklog
+
+go 1.21
+
+require (
+	github.com/mnkd/go-backlog v0.1.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.2.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
+
+// The replace builds against the working tree for development in this
+// repository. It is ignored when otelbacklog is required by other modules,
+// which get the tagged release above.
+replace github.com/mnkd/go-backlog => ../..
@@ -0,0 +1,256 @@
+// Package otelbacklog instruments backlog.Client with OpenTelemetry traces and
+// metrics.
+//
+//	inst, err := otelbacklog.Instrument(client)
+//
+// Every request gets a client span named after the method and the endpoint
+// template, e.g. "GET issues/{issueKey}", and is counted in the metrics below.
+//
+//	backlog.client.requests                 counter of requests
+//	backlog.client.errors                   counter of failed requests
+//	backlog.client.retries                  counter of retries
+//	backlog.client.rate_limit.waits         counter of requests refused by the rate limit
+//	backlog.client.request.duration         histogram of request durations in seconds
+//	backlog.client.rate_limit.wait.duration histogram of the waits until the rate limit resets in seconds
+//
+// A request passed to the middleware again after it failed, e.g. by a
+// retrying middleware added before the instrumentation, is counted as a retry.
+// A request refused with 429 Too Many Requests or with the
+// *backlog.RateLimitError of Client.SetRateLimitCheck is counted as a wait
+// until the reset time of its rate limit.
+package otelbacklog
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and the meter.
+const ScopeName = "github.com/mnkd/go-backlog/backlog/otelbacklog"
+
+// Attribute keys
+const (
+	RouteKey     = attribute.Key("backlog.route")      // e.g. "issues/{issueKey}", or "unknown"
+	ErrorCodeKey = attribute.Key("backlog.error_code") // the code of the first error in backlog.ErrorResponse
+	BucketKey    = attribute.Key("backlog.rate_limit.bucket")
+
+	methodKey = attribute.Key("http.request.method")
+	statusKey = attribute.Key("http.response.status_code")
+	urlKey    = attribute.Key("url.full")
+	serverKey = attribute.Key("server.address")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider. The global one is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the MeterProvider. The global one is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// Instrumentation creates the spans and the metrics of a client.
+type Instrumentation struct {
+	tracer trace.Tracer
+
+	requests     metric.Int64Counter
+	errors       metric.Int64Counter
+	retries      metric.Int64Counter
+	waits        metric.Int64Counter
+	duration     metric.Float64Histogram
+	waitDuration metric.Float64Histogram
+
+	mu     sync.Mutex
+	failed map[*http.Request]bool // the requests whose last attempt failed
+}
+
+// maxFailedRequests is the number of the failed requests remembered to count
+// their retries.
+const maxFailedRequests = 1024
+
+// New returns a new Instrumentation.
+func New(opts ...Option) (*Instrumentation, error) {
+	c := config{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	inst := &Instrumentation{tracer: c.tracerProvider.Tracer(ScopeName), failed: map[*http.Request]bool{}}
+	var err error
+	if inst.requests, err = meter.Int64Counter("backlog.client.requests",
+		metric.WithDescription("Number of requests to the Backlog API")); err != nil {
+		return nil, err
+	}
+	if inst.errors, err = meter.Int64Counter("backlog.client.errors",
+		metric.WithDescription("Number of failed requests to the Backlog API")); err != nil {
+		return nil, err
+	}
+	if inst.retries, err = meter.Int64Counter("backlog.client.retries",
+		metric.WithDescription("Number of retried requests to the Backlog API")); err != nil {
+		return nil, err
+	}
+	if inst.waits, err = meter.Int64Counter("backlog.client.rate_limit.waits",
+		metric.WithDescription("Number of waits for the rate limit of the Backlog API")); err != nil {
+		return nil, err
+	}
+	if inst.duration, err = meter.Float64Histogram("backlog.client.request.duration",
+		metric.WithDescription("Duration of requests to the Backlog API"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if inst.waitDuration, err = meter.Float64Histogram("backlog.client.rate_limit.wait.duration",
+		metric.WithDescription("Duration of waits for the rate limit of the Backlog API"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// Instrument adds the middleware of a new Instrumentation to client.
+func Instrument(client *backlog.Client, opts ...Option) (*Instrumentation, error) {
+	inst, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	client.Use(inst.Middleware())
+	return inst, nil
+}
+
+// Middleware returns the middleware which traces and measures the requests.
+func (inst *Instrumentation) Middleware() backlog.Middleware {
+	return func(next backlog.Doer) backlog.Doer {
+		return backlog.DoerFunc(func(req *http.Request, v interface{}) (*backlog.Response, error) {
+			route := route(req.URL.Path)
+			attrs := []attribute.KeyValue{methodKey.String(req.Method), RouteKey.String(route)}
+			retry := inst.retried(req)
+
+			ctx, span := inst.tracer.Start(req.Context(), req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(urlKey.String(backlog.SanitizeURL(req.URL).String()), serverKey.String(req.URL.Hostname())),
+			)
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next.Do(req.WithContext(ctx), v)
+			elapsed := time.Since(start)
+
+			if resp != nil {
+				attrs = append(attrs, statusKey.Int(resp.StatusCode))
+			}
+			if err != nil {
+				if e, ok := err.(*backlog.ErrorResponse); ok && len(e.Errors) > 0 {
+					attrs = append(attrs, ErrorCodeKey.Int(e.Errors[0].Code))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, errorStatus(resp))
+			}
+			span.SetAttributes(attrs[2:]...)
+
+			set := metric.WithAttributes(attrs...)
+			inst.requests.Add(ctx, 1, set)
+			inst.duration.Record(ctx, elapsed.Seconds(), set)
+			if retry {
+				inst.retries.Add(ctx, 1, metric.WithAttributes(attrs[:2]...))
+			}
+			if err != nil {
+				inst.errors.Add(ctx, 1, set)
+				inst.fail(req)
+			}
+			if bucket, wait, ok := rateLimitWait(resp, err); ok {
+				inst.RecordRateLimitWait(ctx, bucket, wait)
+			}
+			return resp, err
+		})
+	}
+}
+
+func errorStatus(resp *backlog.Response) string {
+	if resp == nil {
+		return "request failed"
+	}
+	return "HTTP " + strconv.Itoa(resp.StatusCode)
+}
+
+// retried reports whether the last attempt of req failed, and forgets it.
+func (inst *Instrumentation) retried(req *http.Request) bool {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	failed := inst.failed[req]
+	delete(inst.failed, req)
+	return failed
+}
+
+// fail remembers that the attempt of req failed.
+func (inst *Instrumentation) fail(req *http.Request) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	if len(inst.failed) >= maxFailedRequests {
+		// The requests not retried so far are unlikely to be retried.
+		inst.failed = map[*http.Request]bool{}
+	}
+	inst.failed[req] = true
+}
+
+// rateLimitWait returns the bucket and the time until the reset of the rate
+// limit which refused the request. The bucket is empty if it is unknown.
+func rateLimitWait(resp *backlog.Response, err error) (backlog.RateLimitBucket, time.Duration, bool) {
+	if e, ok := err.(*backlog.RateLimitError); ok {
+		return e.Bucket, untilReset(e.Rate.Reset), true
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		reset, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		return "", untilReset(reset), true
+	}
+	return "", 0, false
+}
+
+func untilReset(reset int64) time.Duration {
+	if d := time.Until(time.Unix(reset, 0)); reset > 0 && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// RecordRetry counts a retry of req which the middleware does not see, e.g.
+// by an http.RoundTripper of the client.
+func (inst *Instrumentation) RecordRetry(ctx context.Context, req *http.Request) {
+	inst.retries.Add(ctx, 1, metric.WithAttributes(methodKey.String(req.Method), RouteKey.String(route(req.URL.Path))))
+}
+
+// RecordRateLimitWait records a wait for the rate limit of the bucket which
+// the middleware does not see, e.g. by code which waits before requests.
+// The bucket may be empty if it is unknown.
+func (inst *Instrumentation) RecordRateLimitWait(ctx context.Context, bucket backlog.RateLimitBucket, d time.Duration) {
+	var attrs []attribute.KeyValue
+	if len(bucket) > 0 {
+		attrs = append(attrs, BucketKey.String(string(bucket)))
+	}
+	set := metric.WithAttributes(attrs...)
+	inst.waits.Add(ctx, 1, set)
+	inst.waitDuration.Record(ctx, d.Seconds(), set)
+}
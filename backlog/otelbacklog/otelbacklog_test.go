@@ -0,0 +1,179 @@
+package otelbacklog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+	"github.com/mnkd/go-backlog/backlog/backlogtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrument(t *testing.T) {
+	server := backlogtest.NewServer()
+	defer server.Close()
+	server.AddProject("WEB", "Web")
+	server.AddIssue("WEB", backlog.Issue{Summary: "a"})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client := server.Client()
+	// Retry a request once if it fails.
+	client.Use(func(next backlog.Doer) backlog.Doer {
+		return backlog.DoerFunc(func(req *http.Request, v interface{}) (*backlog.Response, error) {
+			resp, err := next.Do(req, v)
+			if err != nil {
+				resp, err = next.Do(req, v)
+			}
+			return resp, err
+		})
+	})
+	_, err := Instrument(client,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Issues.Get("WEB-1")
+	client.Issues.Get("WEB-2")
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(ended))
+	}
+	for _, span := range ended {
+		if span.Name() != "GET issues/{issueKey}" {
+			t.Errorf("span name is %q, want %q", span.Name(), "GET issues/{issueKey}")
+		}
+	}
+	if got := attrs(ended[0].Attributes()); got["http.response.status_code"] != "200" || ended[0].Status().Code == codes.Error {
+		t.Errorf("span of WEB-1 has %v, %v", got, ended[0].Status())
+	}
+	got := attrs(ended[1].Attributes())
+	if got["http.response.status_code"] != "404" || got["backlog.error_code"] != "6" || ended[1].Status().Code != codes.Error {
+		t.Errorf("span of WEB-2 has %v, %v", got, ended[1].Status())
+	}
+	if url := got["url.full"]; url == "" || strings.Contains(url, backlogtest.APIKey) {
+		t.Errorf("span has url.full %q, want the API key redacted", url)
+	}
+
+	want := map[string]int64{
+		"backlog.client.requests":                 3,
+		"backlog.client.errors":                   2,
+		"backlog.client.retries":                  1,
+		"backlog.client.rate_limit.waits":         0,
+		"backlog.client.request.duration":         3,
+		"backlog.client.rate_limit.wait.duration": 0,
+	}
+	sums := collect(t, reader)
+	for name, n := range want {
+		if sums[name] != n {
+			t.Errorf("%s is %d, want %d", name, sums[name], n)
+		}
+	}
+}
+
+func TestInstrument_rateLimit(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	reset := time.Now().Add(time.Minute).Unix()
+	mux.HandleFunc("/api/v2/rateLimit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"rateLimit":{"read":{"limit":600,"remaining":600,"reset":%d},"update":{"limit":150,"remaining":0,"reset":%d}}}`, reset, reset)
+	})
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":[{"message":"Rate limit exceeded.","code":12}]}`)
+	})
+
+	reader := sdkmetric.NewManualReader()
+	client := backlog.NewClient(nil, "example", "apikey")
+	client.BaseURL, _ = url.Parse(server.URL + "/api/v2/")
+	client.SetRateLimitCheck(1)
+	if _, err := Instrument(client, WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Issues.Delete("WEB-1"); err == nil {
+		t.Errorf("Delete returned no error beyond the rate limit")
+	}
+	if _, _, err := client.Issues.Get("WEB-1"); err == nil {
+		t.Errorf("Get returned no error for 429")
+	}
+
+	sums := collect(t, reader)
+	if got := sums["backlog.client.rate_limit.waits"]; got != 2 {
+		t.Errorf("backlog.client.rate_limit.waits is %d, want 2", got)
+	}
+	if got := sums["backlog.client.retries"]; got != 0 {
+		t.Errorf("backlog.client.retries is %d, want 0", got)
+	}
+}
+
+// collect returns the sums of the counters and the counts of the histograms.
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += int64(dp.Count)
+				}
+			}
+		}
+	}
+	return sums
+}
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/api/v2/issues", "issues"},
+		{"/api/v2/issues/WEB-12", "issues/{issueKey}"},
+		{"/api/v2/issues/12345/comments", "issues/{issueKey}/comments"},
+		{"/api/v2/users/myself", "users/myself"},
+		{"/api/v2/users/123/stars/count", "users/{userId}/stars/count"},
+		{"/api/v2/projects/WEB/issueTypes/3", "projects/{projectKey}/issueTypes/{issueTypeId}"},
+		{"/api/v2/issues/WEB-12/sharedFiles/34", "unknown"},
+		{"/api/v2/projects/WEB/wikis/tags", "unknown"},
+		{"/api/v2/documents/0192ff/comments", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := route(tt.in); got != tt.want {
+			t.Errorf("route(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func attrs(kvs []attribute.KeyValue) map[string]string {
+	m := map[string]string{}
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+	return m
+}
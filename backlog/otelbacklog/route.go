@@ -0,0 +1,99 @@
+package otelbacklog
+
+import "strings"
+
+// routes are the templates of the Backlog API paths relative to /api/v2/.
+var routes = []string{
+	"space",
+	"space/activities",
+	"space/diskUsage",
+	"space/image",
+	"space/licence",
+	"space/notification",
+	"rateLimit",
+	"priorities",
+	"statuses",
+	"resolutions",
+	"users",
+	"users/myself",
+	"users/{userId}",
+	"users/{userId}/activities",
+	"users/{userId}/stars",
+	"users/{userId}/stars/count",
+	"users/{userId}/watchings",
+	"users/{userId}/watchings/count",
+	"projects",
+	"projects/{projectKey}",
+	"projects/{projectKey}/activities",
+	"projects/{projectKey}/categories",
+	"projects/{projectKey}/categories/{categoryId}",
+	"projects/{projectKey}/issueTypes",
+	"projects/{projectKey}/issueTypes/{issueTypeId}",
+	"projects/{projectKey}/users",
+	"projects/{projectKey}/versions",
+	"projects/{projectKey}/webhooks",
+	"projects/{projectKey}/webhooks/{webhookId}",
+	"issues",
+	"issues/count",
+	"issues/{issueKey}",
+	"issues/{issueKey}/comments",
+	"issues/{issueKey}/comments/{commentId}",
+	"issues/{issueKey}/participants",
+	"notifications",
+	"notifications/count",
+	"notifications/markAsRead",
+	"notifications/{notificationId}/markAsRead",
+	"watchings",
+	"watchings/{watchingId}",
+	"watchings/{watchingId}/markAsRead",
+	"stars",
+	"stars/{starId}",
+	"documents",
+	"documents/tree",
+	"documents/{documentId}",
+	"documents/{documentId}/attachments/{attachmentId}",
+}
+
+// unknownRoute is the route of the paths not in routes. The paths are not
+// used as they are, since their free-form segments such as project keys and
+// document IDs would make a metric stream per value.
+const unknownRoute = "unknown"
+
+// route returns the template of the URL path, e.g. "issues/{issueKey}" for
+// "/api/v2/issues/WEB-12", so that spans and metrics do not have an attribute
+// value per issue. Literal segments are preferred to parameters.
+// Paths not in routes are "unknown".
+func route(path string) string {
+	if i := strings.Index(path, "/api/v2/"); i >= 0 {
+		path = path[i+len("/api/v2/"):]
+	}
+	path = strings.Trim(path, "/")
+	segments := strings.Split(path, "/")
+
+	best, bestLiterals := "", -1
+	for _, r := range routes {
+		pattern := strings.Split(r, "/")
+		if len(pattern) != len(segments) {
+			continue
+		}
+		literals := 0
+		matched := true
+		for i, p := range pattern {
+			if strings.HasPrefix(p, "{") {
+				continue
+			}
+			if p != segments[i] {
+				matched = false
+				break
+			}
+			literals++
+		}
+		if matched && literals > bestLiterals {
+			best, bestLiterals = r, literals
+		}
+	}
+	if len(best) > 0 {
+		return best
+	}
+	return unknownRoute
+}
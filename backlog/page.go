@@ -0,0 +1,170 @@
+package backlog
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultListCount is the number of items returned by the list APIs without count.
+const defaultListCount = 20
+
+// maxListCount is the maximum number of items returned by the list APIs.
+const maxListCount = 100
+
+// offsetLists are the list APIs paginated by offset, relative to BaseURL.
+var offsetLists = []string{
+	"issues",
+	"documents",
+	"users/*/watchings",
+}
+
+// idLists are the list APIs paginated by minId and maxId, relative to BaseURL.
+var idLists = []string{
+	"space/activities",
+	"projects/*/activities",
+	"users/*/activities",
+	"notifications",
+	"users/*/stars",
+}
+
+// NextPage is the cursor of the next page of a list.
+//
+//	for {
+//		issues, resp, err := client.Issues.Search(request)
+//		...
+//		if resp.NextPage == nil {
+//			break
+//		}
+//		request.Offset = &resp.NextPage.Offset
+//	}
+type NextPage struct {
+	// Offset is set for the lists paginated by offset, e.g. IssuesService.Search.
+	Offset int
+
+	// MaxID or MinID is set for the lists paginated by ID, e.g. activities and
+	// notifications: MaxID in descending order, MinID in ascending order.
+	MaxID int
+	MinID int
+}
+
+func listStyle(relativePath string) string {
+	for _, pattern := range offsetLists {
+		if ok, _ := path.Match(pattern, relativePath); ok {
+			return "offset"
+		}
+	}
+	for _, pattern := range idLists {
+		if ok, _ := path.Match(pattern, relativePath); ok {
+			return "id"
+		}
+	}
+	return ""
+}
+
+// setPage sets the pagination of a list response decoded into v.
+// A full page is assumed to have a next page.
+func (r *Response) setPage(req *http.Request, relativePath string, v interface{}) {
+	if req.Method != "GET" {
+		return
+	}
+	style := listStyle(relativePath)
+	if len(style) == 0 {
+		return
+	}
+	items := reflect.ValueOf(v)
+	for items.Kind() == reflect.Ptr && !items.IsNil() {
+		items = items.Elem()
+	}
+	if items.Kind() != reflect.Slice {
+		return
+	}
+
+	q := req.URL.Query()
+	r.Count = defaultListCount
+	if n, err := strconv.Atoi(q.Get("count")); err == nil && n > 0 {
+		r.Count = n
+	}
+	if r.Count > maxListCount {
+		r.Count = maxListCount
+	}
+	r.Offset, _ = strconv.Atoi(q.Get("offset"))
+	if items.Len() < r.Count || items.Len() == 0 {
+		return
+	}
+
+	if style == "offset" {
+		r.NextPage = &NextPage{Offset: r.Offset + items.Len()}
+		return
+	}
+	minID, maxID := 0, 0
+	for i := 0; i < items.Len(); i++ {
+		id, ok := itemID(items.Index(i))
+		if !ok {
+			return
+		}
+		if i == 0 || id < minID {
+			minID = id
+		}
+		if i == 0 || id > maxID {
+			maxID = id
+		}
+	}
+	if strings.EqualFold(q.Get("order"), "asc") {
+		r.NextPage = &NextPage{MinID: maxID + 1}
+	} else {
+		r.NextPage = &NextPage{MaxID: minID - 1}
+	}
+}
+
+// AllPages calls list with the NextPage of the previous response until the
+// list has no more pages, and returns all the items and the last response.
+// page is nil in the first call.
+//
+// AllPages also stops when a page has no items or the same NextPage as the
+// previous one, so that a list ignoring the cursor does not loop forever.
+//
+//	issues, _, err := backlog.AllPages(func(page *backlog.NextPage) ([]*backlog.Issue, *backlog.Response, error) {
+//		if page != nil {
+//			request.Offset = &page.Offset
+//		}
+//		return client.Issues.Search(request)
+//	})
+func AllPages[T any](list func(page *NextPage) ([]T, *Response, error)) ([]T, *Response, error) {
+	var all []T
+	var page *NextPage
+	for {
+		items, resp, err := list(page)
+		if err != nil {
+			return nil, resp, err
+		}
+		all = append(all, items...)
+		if resp == nil || resp.NextPage == nil || len(items) == 0 {
+			return all, resp, nil
+		}
+		if page != nil && *resp.NextPage == *page {
+			return all, resp, nil
+		}
+		page = resp.NextPage
+	}
+}
+
+// itemID returns the ID field of a list item.
+func itemID(v reflect.Value) (int, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+	f := v.FieldByName("ID")
+	if !f.IsValid() || f.Kind() != reflect.Int {
+		return 0, false
+	}
+	return int(f.Int()), true
+}
@@ -0,0 +1,207 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResponse_metadata(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "150")
+		w.Header().Set("X-RateLimit-Remaining", "149")
+		w.Header().Set("X-RateLimit-Reset", "1577836800")
+		w.Header().Set("X-Request-Id", "abc")
+		if r.URL.Query().Get("offset") == "2" {
+			fmt.Fprint(w, `[{"id":3}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+	})
+
+	count := 2
+	_, resp, err := client.Issues.Search(IssueSearchRequest{Count: &count})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if want := (&RateLimit{Limit: 150, Remaining: 149, Reset: 1577836800}); !reflect.DeepEqual(resp.RateLimit, want) {
+		t.Errorf("RateLimit = %+v, want %+v", resp.RateLimit, want)
+	}
+	if resp.RequestID != "abc" || resp.Duration <= 0 {
+		t.Errorf("RequestID = %q, Duration = %v", resp.RequestID, resp.Duration)
+	}
+	if resp.Offset != 0 || resp.Count != 2 || !reflect.DeepEqual(resp.NextPage, &NextPage{Offset: 2}) {
+		t.Errorf("Offset = %d, Count = %d, NextPage = %+v", resp.Offset, resp.Count, resp.NextPage)
+	}
+
+	_, resp, err = client.Issues.Search(IssueSearchRequest{Count: &count, Offset: &resp.NextPage.Offset})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if resp.Offset != 2 || resp.NextPage != nil {
+		t.Errorf("Offset = %d, NextPage = %+v on the last page", resp.Offset, resp.NextPage)
+	}
+}
+
+func TestResponse_NextPage_id(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/notifications", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":30},{"id":20}]`)
+	})
+
+	count := 2
+	_, resp, err := client.Notifications.List(NotificationListRequest{Count: &count})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if !reflect.DeepEqual(resp.NextPage, &NextPage{MaxID: 19}) {
+		t.Errorf("NextPage = %+v, want MaxID 19", resp.NextPage)
+	}
+
+	asc := "asc"
+	_, resp, _ = client.Notifications.List(NotificationListRequest{Count: &count, Order: &asc})
+	if !reflect.DeepEqual(resp.NextPage, &NextPage{MinID: 31}) {
+		t.Errorf("NextPage = %+v in ascending order, want MinID 31", resp.NextPage)
+	}
+}
+
+func TestResponse_NextPage_countAboveMax(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	const matches = 250
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		// Backlog returns at most 100 issues whatever count is.
+		var offset int
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		n := matches - offset
+		if n > 100 {
+			n = 100
+		}
+		issues := []string{}
+		for i := 0; i < n; i++ {
+			issues = append(issues, fmt.Sprintf(`{"id":%d}`, offset+i))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(issues, ","))
+	})
+
+	count := 150
+	request := IssueSearchRequest{Count: &count}
+	_, resp, err := client.Issues.Search(request)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if resp.Count != 100 || !reflect.DeepEqual(resp.NextPage, &NextPage{Offset: 100}) {
+		t.Errorf("Count = %d, NextPage = %+v with count 150, want 100 and offset 100", resp.Count, resp.NextPage)
+	}
+
+	issues, resp, err := AllPages(func(page *NextPage) ([]*Issue, *Response, error) {
+		if page != nil {
+			request.Offset = &page.Offset
+		}
+		return client.Issues.Search(request)
+	})
+	if err != nil {
+		t.Fatalf("AllPages returned error: %v", err)
+	}
+	if len(issues) != matches || issues[matches-1].ID != matches-1 || resp.Offset != 200 {
+		t.Errorf("AllPages returned %d issues and the last offset %d, want %d and 200", len(issues), resp.Offset, matches)
+	}
+}
+
+func TestAllPages_stuck(t *testing.T) {
+	tests := []struct {
+		name string
+		list func(page *NextPage) ([]int, *Response, error)
+		want int
+	}{
+		{"same next page", func(page *NextPage) ([]int, *Response, error) {
+			return []int{1, 2}, &Response{NextPage: &NextPage{Offset: 2}}, nil
+		}, 4},
+		{"no items", func(page *NextPage) ([]int, *Response, error) {
+			return nil, &Response{NextPage: &NextPage{MaxID: 1}}, nil
+		}, 0},
+	}
+	for _, tt := range tests {
+		calls := 0
+		items, _, err := AllPages(func(page *NextPage) ([]int, *Response, error) {
+			if calls++; calls > 10 {
+				t.Fatalf("%s: AllPages did not stop", tt.name)
+			}
+			return tt.list(page)
+		})
+		if err != nil || len(items) != tt.want {
+			t.Errorf("%s: AllPages returned %v, %v, want %d items", tt.name, items, err, tt.want)
+		}
+	}
+}
+
+func TestResponse_NextPage_countDefault(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	items := []string{}
+	for i := 1; i <= defaultListCount; i++ {
+		items = append(items, fmt.Sprintf(`{"id":%d}`, i))
+	}
+	page := fmt.Sprintf("[%s]", strings.Join(items, ","))
+	mux.HandleFunc("/api/v2/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/api/v2/issues/WEB-1/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	})
+
+	// Backlog returns the default count for count=0.
+	count := 0
+	_, resp, err := client.Issues.Search(IssueSearchRequest{Count: &count})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if resp.Count != defaultListCount || !reflect.DeepEqual(resp.NextPage, &NextPage{Offset: defaultListCount}) {
+		t.Errorf("Count = %d, NextPage = %+v with count 0, want %d", resp.Count, resp.NextPage, defaultListCount)
+	}
+
+	// ListComments cannot request the next page.
+	_, resp, err = client.Issues.ListComments("WEB-1", "desc")
+	if err != nil {
+		t.Fatalf("ListComments returned error: %v", err)
+	}
+	if resp.NextPage != nil {
+		t.Errorf("NextPage = %+v for ListComments, want nil", resp.NextPage)
+	}
+}
+
+func TestTyped(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/WEB-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		fmt.Fprint(w, `{"id":1,"issueKey":"WEB-1"}`)
+	})
+	mux.HandleFunc("/api/v2/issues/WEB-2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"No issue.","code":6,"moreInfo":""}]}`)
+	})
+
+	r, err := Typed(client.Issues.Get("WEB-1"))
+	if err != nil {
+		t.Fatalf("Typed returned error: %v", err)
+	}
+	if r.Value.IssueKey != "WEB-1" || r.RequestID != "abc" || r.StatusCode != http.StatusOK {
+		t.Errorf("Typed returned %+v", r)
+	}
+
+	r, err = Typed(client.Issues.Get("WEB-2"))
+	if err == nil || r == nil || r.StatusCode != http.StatusNotFound {
+		t.Errorf("Typed returned %+v, %v, want the 404 response and the error", r, err)
+	}
+}
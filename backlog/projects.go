@@ -44,9 +44,10 @@ type Version struct {
 
 // User is Backlog user
 type User struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	UserID string `json:"userId"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	UserID      string `json:"userId"`
+	MailAddress string `json:"mailAddress"`
 }
 
 // ListAll lists all projects.
@@ -65,6 +66,24 @@ func (s *ProjectsService) ListAll() ([]*Project, *Response, error) {
 	return projects, resp, nil
 }
 
+// Get gets a project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-project/
+func (s *ProjectsService) Get(projectKey string) (*Project, *Response, error) {
+	u := "projects/" + projectKey
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project := new(Project)
+	resp, err := s.client.Do(req, &project)
+	if err != nil {
+		return nil, resp, err
+	}
+	return project, resp, nil
+}
+
 // ListIssueTypes lists all issueTypes.
 func (s *ProjectsService) ListIssueTypes(projectKey string) ([]*IssueType, *Response, error) {
 	u := "projects/" + projectKey + "/issueTypes"
@@ -0,0 +1,260 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitBucket is the kind of API calls which share a rate limit.
+type RateLimitBucket string
+
+// Rate limit buckets
+const (
+	RateLimitRead   RateLimitBucket = "read"
+	RateLimitUpdate RateLimitBucket = "update"
+	RateLimitSearch RateLimitBucket = "search"
+	RateLimitIcon   RateLimitBucket = "icon"
+)
+
+// RateLimit is the rate limit status of a bucket for the API key.
+type RateLimit struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"` // UNIX time
+}
+
+// ResetTime returns the time when Remaining is reset to Limit.
+func (r RateLimit) ResetTime() time.Time {
+	return time.Unix(r.Reset, 0)
+}
+
+// RateLimits is the rate limit status of all the buckets for the API key.
+type RateLimits struct {
+	Read   RateLimit `json:"read"`
+	Update RateLimit `json:"update"`
+	Search RateLimit `json:"search"`
+	Icon   RateLimit `json:"icon"`
+}
+
+// Bucket returns the rate limit status of the bucket.
+func (r *RateLimits) Bucket(bucket RateLimitBucket) (RateLimit, bool) {
+	switch bucket {
+	case RateLimitRead:
+		return r.Read, true
+	case RateLimitUpdate:
+		return r.Update, true
+	case RateLimitSearch:
+		return r.Search, true
+	case RateLimitIcon:
+		return r.Icon, true
+	}
+	return RateLimit{}, false
+}
+
+// RateLimitError is returned by CheckRateLimit if the bucket does not have
+// enough remaining requests.
+type RateLimitError struct {
+	Bucket   RateLimitBucket
+	Rate     RateLimit
+	Required int
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of %v: %d requests required, but %d of %d remain until %v",
+		e.Bucket, e.Required, e.Rate.Remaining, e.Rate.Limit, e.Rate.ResetTime().Format(time.RFC3339))
+}
+
+// RateLimit gets the rate limit status of the API key.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-rate-limit/
+func (s *SpaceService) RateLimit() (*RateLimits, *Response, error) {
+	u := "rateLimit"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := new(struct {
+		RateLimit *RateLimits `json:"rateLimit"`
+	})
+	resp, err := s.client.Do(req, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	if body.RateLimit == nil {
+		body.RateLimit = new(RateLimits)
+	}
+	return body.RateLimit, resp, nil
+}
+
+// CheckRateLimit checks that the bucket has at least n remaining requests
+// before starting a batch. It returns *RateLimitError if it does not, so the
+// caller can wait until RateLimitError.Rate.ResetTime().
+// Client.SetRateLimitCheck does the check before every request.
+func (s *SpaceService) CheckRateLimit(bucket RateLimitBucket, n int) (*RateLimit, *Response, error) {
+	limits, resp, err := s.RateLimit()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	rate, ok := limits.Bucket(bucket)
+	if !ok {
+		return nil, resp, fmt.Errorf("unknown rate limit bucket %q", bucket)
+	}
+	if rate.Remaining < n {
+		return &rate, resp, &RateLimitError{Bucket: bucket, Rate: rate, Required: n}
+	}
+	return &rate, resp, nil
+}
+
+// SetRateLimitCheck makes the client check that the bucket of each request
+// has at least min remaining requests before sending it. A request beyond
+// the limit fails with *RateLimitError without being sent, so a large batch
+// stops before Backlog starts to respond with 429.
+//
+// The status is fetched with Space.RateLimit before the first request and
+// after the reset time, and is tracked with the X-RateLimit-* headers of the
+// responses in between. Passing 0 disables the check.
+// SetRateLimitCheck must not be called concurrently with requests.
+func (c *Client) SetRateLimitCheck(min int) {
+	if min <= 0 {
+		c.rateLimitCheck = nil
+		return
+	}
+	c.rateLimitCheck = &rateLimitChecker{
+		space:  c.Space,
+		min:    min,
+		now:    time.Now,
+		limits: map[RateLimitBucket]RateLimit{},
+	}
+}
+
+// roundTrip sends req with the HTTP client, after checking the rate limit if
+// SetRateLimitCheck is used.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	checker := c.rateLimitCheck
+	relativePath := c.relativePath(req)
+	if checker == nil || relativePath == "rateLimit" {
+		return c.client.Do(req)
+	}
+
+	bucket := rateLimitBucketOf(req.Method, relativePath)
+	if err := checker.check(bucket); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err == nil {
+		checker.update(bucket, resp.Header)
+	}
+	return resp, err
+}
+
+type rateLimitChecker struct {
+	space *SpaceService
+	min   int
+	now   func() time.Time
+
+	fetchMu sync.Mutex // held while fetching the status
+
+	mu      sync.Mutex
+	limits  map[RateLimitBucket]RateLimit
+	fetches int // number of the fetches of the status
+}
+
+// check returns *RateLimitError if the bucket has less than min remaining
+// requests.
+func (c *rateLimitChecker) check(bucket RateLimitBucket) error {
+	rate, err := c.rate(bucket)
+	if err != nil {
+		return err
+	}
+	if rate.Remaining < c.min {
+		return &RateLimitError{Bucket: bucket, Rate: rate, Required: c.min}
+	}
+	return nil
+}
+
+// update records the status of the bucket after a request.
+func (c *rateLimitChecker) update(bucket RateLimitBucket, header http.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rate, ok := rateLimitFromHeader(header); ok {
+		c.limits[bucket] = rate
+	} else if rate, ok := c.limits[bucket]; ok {
+		rate.Remaining--
+		c.limits[bucket] = rate
+	}
+}
+
+// rate returns the known status of the bucket, and fetches the status if it
+// is unknown or has been reset. Concurrent requests wait for one fetch
+// instead of fetching the status each.
+func (c *rateLimitChecker) rate(bucket RateLimitBucket) (RateLimit, error) {
+	c.mu.Lock()
+	rate, ok := c.limits[bucket]
+	fetches := c.fetches
+	c.mu.Unlock()
+	if ok && c.now().Before(rate.ResetTime()) {
+		return rate, nil
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	c.mu.Lock()
+	if c.fetches != fetches {
+		// Fetched by another request while waiting.
+		rate := c.limits[bucket]
+		c.mu.Unlock()
+		return rate, nil
+	}
+	c.mu.Unlock()
+
+	limits, _, err := c.space.RateLimit()
+	if err != nil {
+		return RateLimit{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetches++
+	c.limits = map[RateLimitBucket]RateLimit{
+		RateLimitRead:   limits.Read,
+		RateLimitUpdate: limits.Update,
+		RateLimitSearch: limits.Search,
+		RateLimitIcon:   limits.Icon,
+	}
+	return c.limits[bucket], nil
+}
+
+// rateLimitBucketOf returns the bucket which the request counts against.
+// Backlog counts the issue searches in the search bucket and the images in
+// the icon bucket.
+func rateLimitBucketOf(method, relativePath string) RateLimitBucket {
+	switch {
+	case strings.HasSuffix(relativePath, "/icon") || relativePath == "space/image":
+		return RateLimitIcon
+	case method != "GET" && method != "HEAD":
+		return RateLimitUpdate
+	case relativePath == "issues" || relativePath == "issues/count":
+		return RateLimitSearch
+	}
+	return RateLimitRead
+}
+
+// rateLimitFromHeader parses the X-RateLimit-* headers of a response.
+func rateLimitFromHeader(h http.Header) (RateLimit, bool) {
+	limit, err1 := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err2 != nil || err3 != nil {
+		return RateLimit{}, false
+	}
+	if err1 != nil {
+		limit = remaining
+	}
+	return RateLimit{Limit: limit, Remaining: remaining, Reset: reset}, true
+}
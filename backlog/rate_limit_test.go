@@ -0,0 +1,157 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestSpaceService_CheckRateLimit(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/rateLimit", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"rateLimit":{
+			"read":{"limit":600,"remaining":598,"reset":1603881873},
+			"update":{"limit":150,"remaining":10,"reset":1603881873},
+			"search":{"limit":150,"remaining":150,"reset":1603881873},
+			"icon":{"limit":60,"remaining":60,"reset":1603881873}}}`)
+	})
+
+	rate, _, err := client.Space.CheckRateLimit(RateLimitRead, 100)
+	if err != nil {
+		t.Fatalf("Space.CheckRateLimit(read) returned error: %v", err)
+	}
+	if rate.Remaining != 598 {
+		t.Errorf("Space.CheckRateLimit(read) returned %+v", rate)
+	}
+
+	_, _, err = client.Space.CheckRateLimit(RateLimitUpdate, 100)
+	e, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("Space.CheckRateLimit(update) returned %v, want *RateLimitError", err)
+	}
+	if e.Rate.Remaining != 10 || e.Required != 100 || e.Rate.ResetTime().Unix() != 1603881873 {
+		t.Errorf("RateLimitError is %+v", e)
+	}
+}
+
+func TestClient_SetRateLimitCheck(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	checks := 0
+	mux.HandleFunc("/api/v2/rateLimit", func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		fmt.Fprint(w, `{"rateLimit":{
+			"read":{"limit":600,"remaining":598,"reset":4102444800},
+			"update":{"limit":150,"remaining":10,"reset":4102444800},
+			"search":{"limit":150,"remaining":150,"reset":4102444800},
+			"icon":{"limit":60,"remaining":60,"reset":4102444800}}}`)
+	})
+	mux.HandleFunc("/api/v2/issues/TEST-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("%s request was sent beyond the rate limit", r.Method)
+		}
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "15")
+		w.Header().Set("X-RateLimit-Reset", "4102444800")
+		fmt.Fprint(w, `{"id":1,"issueKey":"TEST-1"}`)
+	})
+	client.SetRateLimitCheck(20)
+
+	if _, _, err := client.Issues.Get("TEST-1"); err != nil {
+		t.Fatalf("Issues.Get returned error: %v", err)
+	}
+
+	_, _, err := client.Issues.Edit("TEST-1", IssueRequest{Summary: pointers.String("new")})
+	if e, ok := err.(*RateLimitError); !ok || e.Bucket != RateLimitUpdate || e.Rate.Remaining != 10 {
+		t.Errorf("Issues.Edit returned %v, want *RateLimitError of update", err)
+	}
+
+	// The first response tells that 15 read requests remain.
+	_, _, err = client.Issues.Get("TEST-1")
+	if e, ok := err.(*RateLimitError); !ok || e.Bucket != RateLimitRead || e.Rate.Remaining != 15 {
+		t.Errorf("Issues.Get returned %v, want *RateLimitError of read", err)
+	}
+
+	if checks != 1 {
+		t.Errorf("rate limit was fetched %d times, want 1", checks)
+	}
+
+	client.SetRateLimitCheck(0)
+	if _, _, err := client.Issues.Get("TEST-1"); err != nil {
+		t.Errorf("Issues.Get without the check returned error: %v", err)
+	}
+}
+
+func TestClient_SetRateLimitCheck_concurrent(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	var checks int32
+	mux.HandleFunc("/api/v2/rateLimit", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&checks, 1)
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"rateLimit":{"read":{"limit":600,"remaining":598,"reset":4102444800}}}`)
+	})
+	mux.HandleFunc("/api/v2/issues/TEST-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"issueKey":"TEST-1"}`)
+	})
+	client.SetRateLimitCheck(20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Issues.Get("TEST-1"); err != nil {
+				t.Errorf("Issues.Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&checks); got != 1 {
+		t.Errorf("rate limit was fetched %d times, want 1", got)
+	}
+	rate, _ := client.rateLimitCheck.rate(RateLimitRead)
+	if rate.Remaining != 588 {
+		t.Errorf("remaining read requests are %d, want 588", rate.Remaining)
+	}
+
+	// The status is fetched again after the reset time.
+	client.rateLimitCheck.now = func() time.Time { return time.Unix(4102444800, 0) }
+	if _, _, err := client.Issues.Get("TEST-1"); err != nil {
+		t.Errorf("Issues.Get returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&checks); got != 2 {
+		t.Errorf("rate limit was fetched %d times after the reset, want 2", got)
+	}
+}
+
+func TestRateLimitBucketOf(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         RateLimitBucket
+	}{
+		{"GET", "issues/TEST-1", RateLimitRead},
+		{"GET", "issues", RateLimitSearch},
+		{"GET", "issues/count", RateLimitSearch},
+		{"POST", "issues", RateLimitUpdate},
+		{"DELETE", "issues/TEST-1", RateLimitUpdate},
+		{"GET", "users/1/icon", RateLimitIcon},
+		{"GET", "space/image", RateLimitIcon},
+	}
+	for _, tt := range tests {
+		if got := rateLimitBucketOf(tt.method, tt.path); got != tt.want {
+			t.Errorf("rateLimitBucketOf(%v, %v) returned %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package backlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Resolver resolves the names of issue types, categories, versions, users,
+// priorities, statuses and resolutions into their IDs.
+//
+// The lists fetched from the API are cached per project until TTL has passed
+// or they are invalidated.
+// Names are matched exactly first and then case-insensitively.
+// Concurrent lookups of the same list share a single fetch, and lookups of
+// other lists do not wait for it.
+type Resolver struct {
+	client *Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*resolverEntry
+	calls map[string]*resolverCall // fetches in flight
+}
+
+type resolverEntry struct {
+	items   []namedID
+	fetched time.Time
+}
+
+// resolverCall is a fetch of a list shared by the concurrent lookups.
+type resolverCall struct {
+	done  chan struct{}
+	items []namedID
+	err   error
+}
+
+// namedID is an ID and the names which resolve to it.
+type namedID struct {
+	id    int
+	names []string
+}
+
+// Kinds of names resolved by Resolver
+const (
+	kindProject    = "project"
+	kindIssueType  = "issue type"
+	kindCategory   = "category"
+	kindVersion    = "version"
+	kindUser       = "user"
+	kindMyself     = "myself"
+	kindPriority   = "priority"
+	kindStatus     = "status"
+	kindResolution = "resolution"
+)
+
+// ResolveError is returned by Resolver if a name is unknown or ambiguous.
+type ResolveError struct {
+	Kind       string // e.g. "issue type", "user"
+	ProjectKey string // empty for priorities, statuses and resolutions
+	Name       string
+	Ambiguous  bool
+	Candidates []string // matching names if Ambiguous, otherwise all known names
+}
+
+func (e *ResolveError) Error() string {
+	where := ""
+	if len(e.ProjectKey) > 0 {
+		where = " in project " + e.ProjectKey
+	}
+	if e.Ambiguous {
+		return fmt.Sprintf("ambiguous %s %q%s: matches %s", e.Kind, e.Name, where, strings.Join(e.Candidates, ", "))
+	}
+	return fmt.Sprintf("unknown %s %q%s (known: %s)", e.Kind, e.Name, where, strings.Join(e.Candidates, ", "))
+}
+
+// NewResolver returns a new Resolver which caches the lists for ttl.
+// If ttl is zero, the lists are cached until they are invalidated.
+func NewResolver(client *Client, ttl time.Duration) *Resolver {
+	return &Resolver{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  map[string]*resolverEntry{},
+		calls:  map[string]*resolverCall{},
+	}
+}
+
+// ProjectID resolves the project key into the project ID.
+func (r *Resolver) ProjectID(projectKey string) (int, error) {
+	return r.resolve(kindProject, projectKey, projectKey, func() ([]namedID, error) {
+		project, _, err := r.client.Projects.Get(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		return []namedID{{project.ID, []string{project.ProjectKey}}}, nil
+	})
+}
+
+// IssueTypeID resolves the name of an issue type in the project.
+func (r *Resolver) IssueTypeID(projectKey, name string) (int, error) {
+	return r.resolve(kindIssueType, projectKey, name, func() ([]namedID, error) {
+		types, _, err := r.client.Projects.ListIssueTypes(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(types))
+		for _, t := range types {
+			items = append(items, namedID{t.ID, []string{t.Name}})
+		}
+		return items, nil
+	})
+}
+
+// CategoryID resolves the name of a category in the project.
+func (r *Resolver) CategoryID(projectKey, name string) (int, error) {
+	return r.resolve(kindCategory, projectKey, name, func() ([]namedID, error) {
+		categories, _, err := r.client.Projects.ListCategories(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(categories))
+		for _, c := range categories {
+			items = append(items, namedID{c.ID, []string{c.Name}})
+		}
+		return items, nil
+	})
+}
+
+// VersionID resolves the name of a version (milestone) in the project.
+func (r *Resolver) VersionID(projectKey, name string) (int, error) {
+	return r.resolve(kindVersion, projectKey, name, func() ([]namedID, error) {
+		versions, _, err := r.client.Projects.ListVersions(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(versions))
+		for _, v := range versions {
+			items = append(items, namedID{v.ID, []string{v.Name}})
+		}
+		return items, nil
+	})
+}
+
+// UserID resolves a user in the project by the user ID (login name), the name,
+// the mail address or the numeric ID.
+func (r *Resolver) UserID(projectKey, name string) (int, error) {
+	return r.resolve(kindUser, projectKey, name, func() ([]namedID, error) {
+		users, _, err := r.client.Projects.ListUsers(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(users))
+		for _, u := range users {
+			names := []string{u.UserID, u.Name, strconv.Itoa(u.ID)}
+			if len(u.MailAddress) > 0 {
+				names = append(names, u.MailAddress)
+			}
+			items = append(items, namedID{u.ID, names})
+		}
+		return items, nil
+	})
+}
+
+// MyselfID returns the ID of the user who owns the API key.
+func (r *Resolver) MyselfID() (int, error) {
+	return r.resolve(kindMyself, "", "me", func() ([]namedID, error) {
+		user, _, err := r.client.Users.Myself()
+		if err != nil {
+			return nil, err
+		}
+		return []namedID{{user.ID, []string{"me"}}}, nil
+	})
+}
+
+// PriorityID resolves the name of a priority.
+func (r *Resolver) PriorityID(name string) (int, error) {
+	return r.resolve(kindPriority, "", name, func() ([]namedID, error) {
+		priorities, _, err := r.client.Space.ListPriorities()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(priorities))
+		for _, p := range priorities {
+			items = append(items, namedID{p.ID, []string{p.Name}})
+		}
+		return items, nil
+	})
+}
+
+// StatusID resolves the name of a status.
+func (r *Resolver) StatusID(name string) (int, error) {
+	return r.resolve(kindStatus, "", name, func() ([]namedID, error) {
+		statuses, _, err := r.client.Space.ListStatuses()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(statuses))
+		for _, s := range statuses {
+			items = append(items, namedID{s.ID, []string{s.Name}})
+		}
+		return items, nil
+	})
+}
+
+// ResolutionID resolves the name of a resolution.
+func (r *Resolver) ResolutionID(name string) (int, error) {
+	return r.resolve(kindResolution, "", name, func() ([]namedID, error) {
+		resolutions, _, err := r.client.Space.ListResolutions()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]namedID, 0, len(resolutions))
+		for _, s := range resolutions {
+			items = append(items, namedID{s.ID, []string{s.Name}})
+		}
+		return items, nil
+	})
+}
+
+// Invalidate drops the cached lists of the project.
+// Use it after creating or deleting issue types, categories or versions.
+// The lists being fetched are not cached, and later lookups fetch them again.
+func (r *Resolver) Invalidate(projectKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, kind := range []string{kindProject, kindIssueType, kindCategory, kindVersion, kindUser} {
+		delete(r.cache, cacheKey(kind, projectKey))
+		delete(r.calls, cacheKey(kind, projectKey))
+	}
+}
+
+// InvalidateAll drops all the cached lists.
+func (r *Resolver) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = map[string]*resolverEntry{}
+	r.calls = map[string]*resolverCall{}
+}
+
+func cacheKey(kind, projectKey string) string {
+	return kind + "/" + projectKey
+}
+
+func (r *Resolver) resolve(kind, projectKey, name string, fetch func() ([]namedID, error)) (int, error) {
+	items, err := r.list(kind, projectKey, fetch)
+	if err != nil {
+		return 0, err
+	}
+	return match(kind, projectKey, name, items)
+}
+
+// list returns the cached list, or fetches it without holding r.mu.
+func (r *Resolver) list(kind, projectKey string, fetch func() ([]namedID, error)) ([]namedID, error) {
+	key := cacheKey(kind, projectKey)
+
+	r.mu.Lock()
+	if e, ok := r.cache[key]; ok && (r.ttl == 0 || r.now().Sub(e.fetched) < r.ttl) {
+		r.mu.Unlock()
+		return e.items, nil
+	}
+	if c, ok := r.calls[key]; ok {
+		r.mu.Unlock()
+		<-c.done
+		return c.items, c.err
+	}
+	c := &resolverCall{done: make(chan struct{})}
+	if r.calls == nil {
+		r.calls = map[string]*resolverCall{}
+	}
+	r.calls[key] = c
+	r.mu.Unlock()
+
+	c.items, c.err = fetch()
+
+	r.mu.Lock()
+	// The list is not cached if it was invalidated while being fetched.
+	if r.calls[key] == c {
+		delete(r.calls, key)
+		if c.err == nil {
+			if r.cache == nil {
+				r.cache = map[string]*resolverEntry{}
+			}
+			r.cache[key] = &resolverEntry{items: c.items, fetched: r.now()}
+		}
+	}
+	r.mu.Unlock()
+	close(c.done)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.items, nil
+}
+
+// match finds the ID for name in items, trying exact matches before case-insensitive ones.
+func match(kind, projectKey, name string, items []namedID) (int, error) {
+	for _, equal := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		strings.EqualFold,
+	} {
+		var found []namedID
+		for _, item := range items {
+			for _, n := range item.names {
+				if equal(n, name) {
+					found = append(found, item)
+					break
+				}
+			}
+		}
+		if len(found) == 1 {
+			return found[0].id, nil
+		}
+		if len(found) > 1 {
+			candidates := make([]string, 0, len(found))
+			for _, item := range found {
+				candidates = append(candidates, fmt.Sprintf("%s (id:%d)", item.names[0], item.id))
+			}
+			return 0, &ResolveError{Kind: kind, ProjectKey: projectKey, Name: name, Ambiguous: true, Candidates: candidates}
+		}
+	}
+
+	known := make([]string, 0, len(items))
+	for _, item := range items {
+		known = append(known, item.names[0])
+	}
+	return 0, &ResolveError{Kind: kind, ProjectKey: projectKey, Name: name, Candidates: known}
+}
@@ -0,0 +1,133 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/api/v2/projects/WEB/users", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[
+			{"id":1,"userId":"alice","name":"Alice","mailAddress":"alice@example.com"},
+			{"id":2,"userId":"bob","name":"Bob","mailAddress":"bob@example.com"},
+			{"id":3,"userId":"bob2","name":"Bob","mailAddress":"bob2@example.com"}
+		]`)
+	})
+	mux.HandleFunc("/api/v2/projects/WEB/issueTypes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":11,"name":"Bug"},{"id":12,"name":"Task"}]`)
+	})
+
+	resolver := NewResolver(client, time.Minute)
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"alice", 1},
+		{"Alice", 1},
+		{"ALICE", 1},
+		{"bob@example.com", 2},
+		{"bob2", 3},
+		{"3", 3},
+	}
+	for _, tt := range tests {
+		got, err := resolver.UserID("WEB", tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("UserID(%q) returned %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("users are fetched %d times, want 1", requests)
+	}
+
+	_, err := resolver.UserID("WEB", "Bob")
+	if e, ok := err.(*ResolveError); !ok || !e.Ambiguous || len(e.Candidates) != 2 {
+		t.Errorf("UserID(Bob) returned %v, want an ambiguous ResolveError", err)
+	}
+
+	_, err = resolver.IssueTypeID("WEB", "Bugg")
+	if e, ok := err.(*ResolveError); !ok || e.Ambiguous || e.Kind != "issue type" {
+		t.Errorf("IssueTypeID(Bugg) returned %v, want an unknown ResolveError", err)
+	} else if got, want := e.Error(), `unknown issue type "Bugg" in project WEB (known: Bug, Task)`; got != want {
+		t.Errorf("ResolveError.Error() returned %q, want %q", got, want)
+	}
+
+	now = now.Add(2 * time.Minute)
+	resolver.UserID("WEB", "alice")
+	if requests != 2 {
+		t.Errorf("users are fetched %d times after TTL, want 2", requests)
+	}
+
+	resolver.Invalidate("WEB")
+	resolver.UserID("WEB", "alice")
+	if requests != 3 {
+		t.Errorf("users are fetched %d times after Invalidate, want 3", requests)
+	}
+}
+
+func TestResolver_concurrentFetch(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+	mux.HandleFunc("/api/v2/projects/WEB/issueTypes", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-release
+		fmt.Fprint(w, `[{"id":11,"name":"Bug"}]`)
+	})
+	mux.HandleFunc("/api/v2/projects/APP/issueTypes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":21,"name":"Bug"}]`)
+	})
+
+	resolver := NewResolver(client, 0)
+
+	var wg sync.WaitGroup
+	ids := make([]int, 3)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], _ = resolver.IssueTypeID("WEB", "Bug")
+		}(i)
+	}
+
+	// Lookups of another project and invalidation do not wait for the fetch of WEB.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if id, err := resolver.IssueTypeID("APP", "Bug"); err != nil || id != 21 {
+			t.Errorf("IssueTypeID(APP, Bug) returned %d, %v, want 21", id, err)
+		}
+		resolver.Invalidate("APP")
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("IssueTypeID(APP) waited for the fetch of another project")
+	}
+
+	close(release)
+	wg.Wait()
+	for i, id := range ids {
+		if id != 11 {
+			t.Errorf("IssueTypeID(WEB, Bug) #%d returned %d, want 11", i, id)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("issue types of WEB are fetched %d times, want 1", requests)
+	}
+}
@@ -1,8 +1,105 @@
 package backlog
 
+import (
+	"io"
+	"mime"
+	"net/url"
+	"time"
+)
+
 // SpaceService is
 type SpaceService service
 
+// Space is Backlog space
+type Space struct {
+	SpaceKey           string    `json:"spaceKey"`
+	Name               string    `json:"name"`
+	OwnerID            int       `json:"ownerId"`
+	Lang               string    `json:"lang"`
+	Timezone           string    `json:"timezone"`
+	ReportSendTime     string    `json:"reportSendTime"`
+	TextFormattingRule string    `json:"textFormattingRule"`
+	Created            time.Time `json:"created"`
+	Updated            time.Time `json:"updated"`
+}
+
+// SpaceNotification is the notice shown to the users of the Backlog space
+type SpaceNotification struct {
+	Content string    `json:"content"`
+	Updated time.Time `json:"updated"`
+}
+
+// DiskUsage is the disk usage of the Backlog space in bytes
+type DiskUsage struct {
+	Capacity   int64              `json:"capacity"`
+	Issue      int64              `json:"issue"`
+	Wiki       int64              `json:"wiki"`
+	File       int64              `json:"file"`
+	Subversion int64              `json:"subversion"`
+	Git        int64              `json:"git"`
+	GitLFS     int64              `json:"gitLFS"`
+	Details    []ProjectDiskUsage `json:"details"`
+}
+
+// ProjectDiskUsage is the disk usage of a project in bytes
+type ProjectDiskUsage struct {
+	ProjectID  int   `json:"projectId"`
+	Issue      int64 `json:"issue"`
+	Wiki       int64 `json:"wiki"`
+	File       int64 `json:"file"`
+	Subversion int64 `json:"subversion"`
+	Git        int64 `json:"git"`
+	GitLFS     int64 `json:"gitLFS"`
+}
+
+// Used returns the total disk usage of the space.
+func (u *DiskUsage) Used() int64 {
+	return u.Issue + u.Wiki + u.File + u.Subversion + u.Git + u.GitLFS
+}
+
+// Remaining returns the remaining capacity of the space.
+func (u *DiskUsage) Remaining() int64 {
+	return u.Capacity - u.Used()
+}
+
+// Used returns the total disk usage of the project.
+func (u *ProjectDiskUsage) Used() int64 {
+	return u.Issue + u.Wiki + u.File + u.Subversion + u.Git + u.GitLFS
+}
+
+// Licence is the licence of the Backlog space
+type Licence struct {
+	Active                 bool      `json:"active"`
+	LicenceTypeID          int       `json:"licenceTypeId"`
+	UserLimit              int       `json:"userLimit"`
+	ProjectLimit           int       `json:"projectLimit"`
+	IssueLimit             int       `json:"issueLimit"`
+	StorageLimit           int64     `json:"storageLimit"`
+	AttachmentLimit        int64     `json:"attachmentLimit"`
+	AttachmentLimitPerFile int64     `json:"attachmentLimitPerFile"`
+	AttachmentNumLimit     int       `json:"attachmentNumLimit"`
+	Attribute              bool      `json:"attribute"`
+	AttributeLimit         int       `json:"attributeLimit"`
+	Burndown               bool      `json:"burndown"`
+	CommentLimit           int       `json:"commentLimit"`
+	ComponentLimit         int       `json:"componentLimit"`
+	FileSharing            bool      `json:"fileSharing"`
+	Gantt                  bool      `json:"gantt"`
+	Git                    bool      `json:"git"`
+	NulabAccount           bool      `json:"nulabAccount"`
+	ParentChild            bool      `json:"parentChild"`
+	PostIssueByMail        bool      `json:"postIssueByMail"`
+	ProjectGroup           bool      `json:"projectGroup"`
+	RemoteAddress          bool      `json:"remoteAddress"`
+	RemoteAddressLimit     int       `json:"remoteAddressLimit"`
+	Subversion             bool      `json:"subversion"`
+	SubversionExternal     bool      `json:"subversionExternal"`
+	Wiki                   bool      `json:"wiki"`
+	WikiAttachment         bool      `json:"wikiAttachment"`
+	StartedOn              time.Time `json:"startedOn"`
+	LimitDate              time.Time `json:"limitDate"`
+}
+
 // Priority is Backlog priority types in the Backlog space
 type Priority struct {
 	ID   int    `json:"id"`
@@ -74,3 +171,122 @@ func (s *SpaceService) ListResolutions() ([]*Resolution, *Response, error) {
 	}
 	return resolutions, resp, nil
 }
+
+// Get gets the space.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-space/
+func (s *SpaceService) Get() (*Space, *Response, error) {
+	u := "space"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	space := new(Space)
+	resp, err := s.client.Do(req, &space)
+	if err != nil {
+		return nil, resp, err
+	}
+	return space, resp, nil
+}
+
+// GetNotification gets the notice of the space.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-space-notification/
+func (s *SpaceService) GetNotification() (*SpaceNotification, *Response, error) {
+	u := "space/notification"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notification := new(SpaceNotification)
+	resp, err := s.client.Do(req, &notification)
+	if err != nil {
+		return nil, resp, err
+	}
+	return notification, resp, nil
+}
+
+// UpdateNotification updates the notice of the space.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/update-space-notification/
+func (s *SpaceService) UpdateNotification(content string) (*SpaceNotification, *Response, error) {
+	u := "space/notification"
+	v := url.Values{}
+	v.Set("content", content)
+
+	req, err := s.client.NewRequest("PUT", u, &v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notification := new(SpaceNotification)
+	resp, err := s.client.Do(req, &notification)
+	if err != nil {
+		return nil, resp, err
+	}
+	return notification, resp, nil
+}
+
+// GetDiskUsage gets the disk usage of the space and its projects.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-space-disk-usage/
+func (s *SpaceService) GetDiskUsage() (*DiskUsage, *Response, error) {
+	u := "space/diskUsage"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage := new(DiskUsage)
+	resp, err := s.client.Do(req, &usage)
+	if err != nil {
+		return nil, resp, err
+	}
+	return usage, resp, nil
+}
+
+// GetLicence gets the licence of the space.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-licence/
+func (s *SpaceService) GetLicence() (*Licence, *Response, error) {
+	u := "space/licence"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	licence := new(Licence)
+	resp, err := s.client.Do(req, &licence)
+	if err != nil {
+		return nil, resp, err
+	}
+	return licence, resp, nil
+}
+
+// DownloadLogo writes the logo image of the space to w, and returns its file name.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-space-logo/
+func (s *SpaceService) DownloadLogo(w io.Writer) (string, *Response, error) {
+	u := "space/image"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := s.client.Do(req, w)
+	if err != nil {
+		return "", resp, err
+	}
+	return filename(resp), resp, nil
+}
+
+// filename returns the file name in the Content-Disposition header of resp.
+func filename(resp *Response) string {
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err != nil {
+		return ""
+	}
+	return params["filename"]
+}
@@ -0,0 +1,57 @@
+package backlog
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSpaceService_GetDiskUsage(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/space/diskUsage", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"capacity":1073741824,"issue":119511,"wiki":48575,"file":0,"subversion":0,"git":0,"gitLFS":0,
+			"details":[{"projectId":1,"issue":11931,"wiki":0,"file":0,"subversion":0,"git":0,"gitLFS":0}]}`)
+	})
+
+	usage, _, err := client.Space.GetDiskUsage()
+	if err != nil {
+		t.Fatalf("Space.GetDiskUsage returned error: %v", err)
+	}
+	if got, want := usage.Used(), int64(119511+48575); got != want {
+		t.Errorf("DiskUsage.Used() returned %d, want %d", got, want)
+	}
+	if got, want := usage.Remaining(), int64(1073741824-119511-48575); got != want {
+		t.Errorf("DiskUsage.Remaining() returned %d, want %d", got, want)
+	}
+	if len(usage.Details) != 1 || usage.Details[0].Used() != 11931 {
+		t.Errorf("DiskUsage.Details is %+v", usage.Details)
+	}
+}
+
+func TestSpaceService_DownloadLogo(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/space/image", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Disposition", `attachment; filename="logo_mark.png"`)
+		fmt.Fprint(w, "\x89PNG")
+	})
+
+	var buf bytes.Buffer
+	name, _, err := client.Space.DownloadLogo(&buf)
+	if err != nil {
+		t.Fatalf("Space.DownloadLogo returned error: %v", err)
+	}
+	if name != "logo_mark.png" {
+		t.Errorf("Space.DownloadLogo returned file name %q, want logo_mark.png", name)
+	}
+	if got := buf.String(); got != "\x89PNG" {
+		t.Errorf("Space.DownloadLogo wrote %q", got)
+	}
+}
@@ -0,0 +1,142 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// StarsService is
+type StarsService service
+
+// Star is Backlog star given to an issue, a comment, a wiki or a pull request
+type Star struct {
+	ID        int       `json:"id"`
+	Comment   string    `json:"comment"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Presenter User      `json:"presenter"`
+	Created   time.Time `json:"created"`
+}
+
+// StarRequest represents a request to add a star.
+// Exactly one of the IDs must be set.
+type StarRequest struct {
+	IssueID              *int
+	CommentID            *int
+	WikiID               *int
+	PullRequestID        *int
+	PullRequestCommentID *int
+}
+
+// StarListRequest represents a request to list stars received by a user.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-received-star-list/
+type StarListRequest struct {
+	MinID *int    `url:"minId,omitempty"` // 最小ID
+	MaxID *int    `url:"maxId,omitempty"` // 最大ID
+	Count *int    `url:"count,omitempty"` // 取得上限 (1-100) 指定が無い場合は 20
+	Order *string `url:"order,omitempty"` // `asc` または `desc` 指定が無い場合は `desc`
+}
+
+// StarCountRequest represents a request to count stars received by a user.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-user-received-stars/
+type StarCountRequest struct {
+	Since *string `url:"since,omitempty"` // 集計開始日 (yyyy-MM-dd)
+	Until *string `url:"until,omitempty"` // 集計終了日 (yyyy-MM-dd)
+}
+
+// Add adds a star to an issue, a comment, a wiki or a pull request.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/add-star/
+func (s *StarsService) Add(request StarRequest) (*Response, error) {
+	u := "stars"
+	v := request.makeValues()
+	if len(v) != 1 {
+		return nil, fmt.Errorf("StarRequest must have exactly one target, but %d are set", len(v))
+	}
+
+	req, err := s.client.NewRequest("POST", u, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Remove removes a star.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/remove-star/
+func (s *StarsService) Remove(starID int) (*Response, error) {
+	u := fmt.Sprintf("stars/%d", starID)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// ListReceived lists stars received by the user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-received-star-list/
+func (s *StarsService) ListReceived(userID int, request StarListRequest) ([]*Star, *Response, error) {
+	u, _ := addOptions(fmt.Sprintf("users/%d/stars", userID), request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stars := []*Star{}
+	resp, err := s.client.Do(req, &stars)
+	if err != nil {
+		return nil, resp, err
+	}
+	return stars, resp, nil
+}
+
+// CountReceived returns the number of stars received by the user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-user-received-stars/
+func (s *StarsService) CountReceived(userID int, request StarCountRequest) (int, *Response, error) {
+	u, _ := addOptions(fmt.Sprintf("users/%d/stars/count", userID), request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count := new(countResponse)
+	resp, err := s.client.Do(req, &count)
+	if err != nil {
+		return 0, resp, err
+	}
+	return count.Count, resp, nil
+}
+
+func (r StarRequest) makeValues() url.Values {
+	v := url.Values{}
+	if r.IssueID != nil {
+		v.Set("issueId", fmt.Sprintf("%d", *r.IssueID))
+	}
+	if r.CommentID != nil {
+		v.Set("commentId", fmt.Sprintf("%d", *r.CommentID))
+	}
+	if r.WikiID != nil {
+		v.Set("wikiId", fmt.Sprintf("%d", *r.WikiID))
+	}
+	if r.PullRequestID != nil {
+		v.Set("pullRequestId", fmt.Sprintf("%d", *r.PullRequestID))
+	}
+	if r.PullRequestCommentID != nil {
+		v.Set("pullRequestCommentId", fmt.Sprintf("%d", *r.PullRequestCommentID))
+	}
+
+	return v
+}
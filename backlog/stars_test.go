@@ -0,0 +1,36 @@
+package backlog
+
+import (
+	"net/http"
+	"testing"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestStarsService_Add(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/stars", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.FormValue("commentId"), "42"; got != want {
+			t.Errorf("commentId: %v, want %v", got, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Stars.Add(StarRequest{CommentID: pointers.Int(42)}); err != nil {
+		t.Errorf("Stars.Add returned error: %v", err)
+	}
+}
+
+func TestStarsService_Add_invalidTarget(t *testing.T) {
+	client := NewClient(nil, "example", "secret")
+
+	if _, err := client.Stars.Add(StarRequest{}); err == nil {
+		t.Error("Stars.Add with no target returned nil error")
+	}
+	if _, err := client.Stars.Add(StarRequest{IssueID: pointers.Int(1), WikiID: pointers.Int(2)}); err == nil {
+		t.Error("Stars.Add with two targets returned nil error")
+	}
+}
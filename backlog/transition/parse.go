@@ -0,0 +1,207 @@
+// Package transition updates Backlog issues by commands in commit messages,
+// such as "fixes WEB-12" or "WEB-12 #close #comment deployed".
+package transition
+
+import (
+	"strings"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// Action is the change of an issue triggered by a keyword.
+// IDs take precedence over names, which are resolved with backlog.Resolver.
+type Action struct {
+	Status       string
+	StatusID     *int
+	Resolution   string
+	ResolutionID *int
+}
+
+// Config configures the keywords of commit messages.
+//
+// A keyword without "#" such as "fixes" applies to the issue keys following it,
+// as in "fixes WEB-12, WEB-13". A keyword starting with "#" such as "#close"
+// applies to the issue key before it, as in "WEB-12 #close".
+// The text after CommentTag up to the end of the line is added as a comment.
+type Config struct {
+	// Keywords maps lower case keywords to actions.
+	Keywords map[string]Action
+
+	// Projects overrides Keywords per project key.
+	Projects map[string]map[string]Action
+
+	// CommentTag is the tag of comments. "#comment" if empty.
+	CommentTag string
+
+	// Extractor finds issue keys. The zero IssueKeyExtractor if nil.
+	Extractor *backlog.IssueKeyExtractor
+}
+
+// Built-in status and resolution IDs which exist in every Backlog space
+const (
+	statusInProgress = 2
+	statusResolved   = 3
+	statusClosed     = 4
+	resolutionFixed  = 0
+)
+
+// DefaultConfig returns a Config with the common keywords for the built-in
+// statuses: fix(es|ed), close(s|d), #close, resolve(s|d), #resolve and #start.
+func DefaultConfig() *Config {
+	id := func(i int) *int { return &i }
+	closed := Action{StatusID: id(statusClosed), ResolutionID: id(resolutionFixed)}
+	resolved := Action{StatusID: id(statusResolved), ResolutionID: id(resolutionFixed)}
+	inProgress := Action{StatusID: id(statusInProgress)}
+
+	return &Config{
+		Keywords: map[string]Action{
+			"fix":      closed,
+			"fixes":    closed,
+			"fixed":    closed,
+			"close":    closed,
+			"closes":   closed,
+			"closed":   closed,
+			"#close":   closed,
+			"resolve":  resolved,
+			"resolves": resolved,
+			"resolved": resolved,
+			"#resolve": resolved,
+			"#start":   inProgress,
+		},
+	}
+}
+
+// Command is the instruction for an issue in a commit message.
+type Command struct {
+	Key      backlog.IssueKey
+	Keywords []string // matched keywords in order, e.g. "fixes", "#close"
+	Comment  string
+}
+
+func (c *Config) commentTag() string {
+	if len(c.CommentTag) > 0 {
+		return strings.ToLower(c.CommentTag)
+	}
+	return "#comment"
+}
+
+// action returns the action of the keyword for the project.
+func (c *Config) action(projectKey, keyword string) (Action, bool) {
+	if actions, ok := c.Projects[projectKey]; ok {
+		if a, ok := actions[keyword]; ok {
+			return a, true
+		}
+	}
+	a, ok := c.Keywords[keyword]
+	return a, ok
+}
+
+func (c *Config) isKeyword(projectKey, keyword string) bool {
+	_, ok := c.action(projectKey, keyword)
+	return ok
+}
+
+// Parse finds the commands in a commit message.
+// Issue keys without keywords or comments are returned as commands without changes.
+func (c *Config) Parse(message string) []Command {
+	extractor := c.Extractor
+	if extractor == nil {
+		extractor = &backlog.IssueKeyExtractor{}
+	}
+	matches := extractor.ExtractMatches(message)
+
+	commands := []Command{}
+	commentEnd := -1
+	var prevPrefix []string
+	for i, m := range matches {
+		if m.Start < commentEnd {
+			continue
+		}
+		lineStart := strings.LastIndex(message[:m.Start], "\n") + 1
+		lineEnd := len(message)
+		if j := strings.Index(message[m.End:], "\n"); j >= 0 {
+			lineEnd = m.End + j
+		}
+		projectKey := m.Key.ProjectKey()
+
+		// Keywords before the key, or the keywords of the previous key
+		// if they are separated only by connectors as in "fixes WEB-1, WEB-2".
+		prevEnd := lineStart
+		if i > 0 && matches[i-1].End > lineStart {
+			prevEnd = matches[i-1].End
+		}
+		var prefix []string
+		words := splitWords(message[prevEnd:m.Start])
+		if onlyConnectors(words) && prevEnd > lineStart {
+			prefix = prevPrefix
+		} else if len(words) > 0 {
+			if w := strings.ToLower(words[len(words)-1]); !strings.HasPrefix(w, "#") && c.isKeyword(projectKey, w) {
+				prefix = []string{w}
+			}
+		}
+		prevPrefix = prefix
+
+		// Hashtags after the key up to the next key on the line.
+		end := lineEnd
+		if i+1 < len(matches) && matches[i+1].Start < lineEnd {
+			end = matches[i+1].Start
+		}
+		cmd := Command{Key: m.Key, Keywords: append([]string{}, prefix...)}
+		words, offsets := splitWordsWithOffsets(message[m.End:end])
+		for j, w := range words {
+			tag := strings.ToLower(w)
+			if strings.EqualFold(w, c.commentTag()) {
+				start := m.End + offsets[j] + len(w)
+				cmd.Comment = strings.TrimSpace(message[start:lineEnd])
+				commentEnd = lineEnd
+				break
+			}
+			if strings.HasPrefix(tag, "#") && c.isKeyword(projectKey, tag) {
+				cmd.Keywords = append(cmd.Keywords, tag)
+			}
+		}
+		commands = append(commands, cmd)
+	}
+	return commands
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, isWordSeparator)
+}
+
+// splitWordsWithOffsets is splitWords which also returns the byte offsets of
+// the words in s.
+func splitWordsWithOffsets(s string) (words []string, offsets []int) {
+	start := -1
+	for i, r := range s {
+		if !isWordSeparator(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			words, offsets = append(words, s[start:i]), append(offsets, start)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words, offsets = append(words, s[start:]), append(offsets, start)
+	}
+	return words, offsets
+}
+
+func isWordSeparator(r rune) bool {
+	return strings.ContainsRune(" \t\r\n,;:()[]", r)
+}
+
+func onlyConnectors(words []string) bool {
+	for _, w := range words {
+		switch strings.ToLower(w) {
+		case "and", "&", "+":
+		default:
+			return false
+		}
+	}
+	return true
+}
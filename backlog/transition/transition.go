@@ -0,0 +1,139 @@
+package transition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// Transitioner applies the commands in commit messages to issues.
+type Transitioner struct {
+	Client *backlog.Client
+
+	// Resolver resolves the names of statuses and resolutions in actions.
+	// It is not needed if the actions have IDs only.
+	Resolver *backlog.Resolver
+
+	Config *Config
+
+	// DryRun reports what would happen without updating issues.
+	DryRun bool
+}
+
+// Result is the result of a command.
+type Result struct {
+	Command Command
+	Request backlog.IssueRequest
+	Summary string // e.g. `WEB-12: status 4, resolution 0, comment "deployed"`
+	Applied bool
+	Err     error
+}
+
+// Apply applies the commands in message in order. Commands without changes are
+// skipped. It returns an error if any command fails, and the results of all
+// the commands regardless.
+func (t *Transitioner) Apply(message string) ([]Result, error) {
+	config := t.Config
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	results := []Result{}
+	failed := 0
+	for _, cmd := range config.Parse(message) {
+		result := Result{Command: cmd}
+		result.Request, result.Summary, result.Err = t.request(config, cmd)
+		if result.Err == nil && len(result.Summary) > 0 && !t.DryRun {
+			result.Err = t.apply(cmd, result.Request)
+			result.Applied = result.Err == nil
+		}
+		if result.Err != nil {
+			failed++
+		}
+		if len(result.Summary) > 0 || result.Err != nil {
+			results = append(results, result)
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("transition: %d of %d commands failed", failed, len(results))
+	}
+	return results, nil
+}
+
+// request builds the IssueRequest of cmd and its summary. The summary is
+// empty if cmd has no changes.
+func (t *Transitioner) request(config *Config, cmd Command) (backlog.IssueRequest, string, error) {
+	var action Action
+	for _, keyword := range cmd.Keywords {
+		a, _ := config.action(cmd.Key.ProjectKey(), keyword)
+		if a.StatusID != nil || len(a.Status) > 0 {
+			action.Status, action.StatusID = a.Status, a.StatusID
+		}
+		if a.ResolutionID != nil || len(a.Resolution) > 0 {
+			action.Resolution, action.ResolutionID = a.Resolution, a.ResolutionID
+		}
+	}
+
+	request := backlog.IssueRequest{}
+	var changes []string
+	if action.StatusID != nil || len(action.Status) > 0 {
+		id, err := t.resolve(action.StatusID, action.Status, func(r *backlog.Resolver, name string) (int, error) {
+			return r.StatusID(name)
+		})
+		if err != nil {
+			return request, "", fmt.Errorf("%v: %v", cmd.Key, err)
+		}
+		request.StatusID = &id
+		changes = append(changes, "status "+describe(action.Status, id))
+	}
+	if action.ResolutionID != nil || len(action.Resolution) > 0 {
+		id, err := t.resolve(action.ResolutionID, action.Resolution, func(r *backlog.Resolver, name string) (int, error) {
+			return r.ResolutionID(name)
+		})
+		if err != nil {
+			return request, "", fmt.Errorf("%v: %v", cmd.Key, err)
+		}
+		request.ResolutionID = &id
+		changes = append(changes, "resolution "+describe(action.Resolution, id))
+	}
+	if len(cmd.Comment) > 0 {
+		comment := cmd.Comment
+		request.Comment = &comment
+		changes = append(changes, fmt.Sprintf("comment %q", comment))
+	}
+
+	if len(changes) == 0 {
+		return request, "", nil
+	}
+	return request, cmd.Key.String() + ": " + strings.Join(changes, ", "), nil
+}
+
+func (t *Transitioner) resolve(id *int, name string, fn func(*backlog.Resolver, string) (int, error)) (int, error) {
+	if id != nil {
+		return *id, nil
+	}
+	if t.Resolver == nil {
+		return 0, fmt.Errorf("cannot resolve %q without Resolver", name)
+	}
+	return fn(t.Resolver, name)
+}
+
+func describe(name string, id int) string {
+	if len(name) > 0 {
+		return name + " (" + strconv.Itoa(id) + ")"
+	}
+	return strconv.Itoa(id)
+}
+
+func (t *Transitioner) apply(cmd Command, request backlog.IssueRequest) error {
+	key := cmd.Key.String()
+	if request.StatusID == nil && request.ResolutionID == nil {
+		_, _, err := t.Client.Issues.CreateComment(key, *request.Comment)
+		return err
+	}
+	_, _, err := t.Client.Issues.Edit(key, request)
+	return err
+}
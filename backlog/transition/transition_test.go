@@ -0,0 +1,105 @@
+package transition
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string // "KEY keywords|comment"
+	}{
+		{"fixes WEB-12", []string{"WEB-12 [fixes]|"}},
+		{"Fixes WEB-12, WEB-13 and API-1", []string{"WEB-12 [fixes]|", "WEB-13 [fixes]|", "API-1 [fixes]|"}},
+		{"WEB-12 #close #comment deployed to prod", []string{"WEB-12 [#close]|deployed to prod"}},
+		{"WEB-12 #comment see WEB-13 too", []string{"WEB-12 []|see WEB-13 too"}},
+		{"WEB-12 #commentary #comment deployed", []string{"WEB-12 []|deployed"}},
+		{"WEB-12 İİİİ #comment deployed now", []string{"WEB-12 []|deployed now"}},
+		{"WEB-12 #Comment Deployed", []string{"WEB-12 []|Deployed"}},
+		{"refs WEB-12\nresolves WEB-13 #start", []string{"WEB-12 []|", "WEB-13 [resolves #start]|"}},
+		{"WEB-1 #close WEB-2", []string{"WEB-1 [#close]|", "WEB-2 []|"}},
+		{"fix `WEB-1` in https://example.com/WEB-2", []string{}},
+	}
+
+	config := DefaultConfig()
+	for _, tt := range tests {
+		got := []string{}
+		for _, c := range config.Parse(tt.message) {
+			got = append(got, fmt.Sprintf("%v %v|%v", c.Key, c.Keywords, c.Comment))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) returned %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestTransitioner_Apply(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := backlog.NewClient(nil, "example", "secret")
+	client.BaseURL, _ = url.Parse(server.URL + "/api/v2/")
+
+	mux.HandleFunc("/api/v2/statuses", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":1,"name":"未対応"},{"id":4,"name":"完了"},{"id":5,"name":"Deployed"}]`)
+	})
+	edits := map[string]url.Values{}
+	mux.HandleFunc("/api/v2/issues/", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		edits[r.Method+" "+r.URL.Path] = r.PostForm
+		fmt.Fprint(w, `{}`)
+	})
+
+	deployed := Action{Status: "deployed"}
+	config := DefaultConfig()
+	config.Projects = map[string]map[string]Action{"WEB": {"#deploy": deployed}}
+
+	tr := &Transitioner{Client: client, Resolver: backlog.NewResolver(client, 0), Config: config, DryRun: true}
+	message := "fixes WEB-12\nWEB-13 #deploy #comment shipped\nAPI-1 #comment looks good\nmentions WEB-14"
+
+	results, err := tr.Apply(message)
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	want := []string{
+		"WEB-12: status 4, resolution 0",
+		`WEB-13: status deployed (5), comment "shipped"`,
+		`API-1: comment "looks good"`,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Apply returned %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, r := range results {
+		if r.Summary != want[i] || r.Applied {
+			t.Errorf("results[%d] is %q applied:%v, want %q", i, r.Summary, r.Applied, want[i])
+		}
+	}
+	if len(edits) != 0 {
+		t.Errorf("dry run updated issues: %v", edits)
+	}
+
+	tr.DryRun = false
+	if _, err := tr.Apply(message); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if got := edits["PATCH /api/v2/issues/WEB-12"]; got.Get("statusId") != "4" || got.Get("resolutionId") != "0" {
+		t.Errorf("WEB-12 is updated with %v", got)
+	}
+	if got := edits["PATCH /api/v2/issues/WEB-13"]; got.Get("statusId") != "5" || got.Get("comment") != "shipped" {
+		t.Errorf("WEB-13 is updated with %v", got)
+	}
+	if got := edits["POST /api/v2/issues/API-1/comments"]; got.Get("content") != "looks good" {
+		t.Errorf("API-1 is commented with %v", got)
+	}
+	if len(edits) != 3 {
+		t.Errorf("Apply sent %d requests, want 3: %v", len(edits), edits)
+	}
+}
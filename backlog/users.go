@@ -0,0 +1,22 @@
+package backlog
+
+// UsersService is
+type UsersService service
+
+// Myself gets the user who owns the API key.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-own-user/
+func (s *UsersService) Myself() (*User, *Response, error) {
+	u := "users/myself"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(req, &user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return user, resp, nil
+}
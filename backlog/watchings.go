@@ -0,0 +1,198 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WatchingsService is
+type WatchingsService service
+
+// Watching is Backlog watching of an issue
+type Watching struct {
+	ID                  int       `json:"id"`
+	ResourceAlreadyRead bool      `json:"resourceAlreadyRead"`
+	Note                string    `json:"note"`
+	Type                string    `json:"type"`
+	Issue               *Issue    `json:"issue"`
+	LastContentUpdated  time.Time `json:"lastContentUpdated"`
+	Created             time.Time `json:"created"`
+	Updated             time.Time `json:"updated"`
+}
+
+// WatchingListRequest represents a request to list watchings.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-watching-list/
+type WatchingListRequest struct {
+	Order               *string `url:"order,omitempty"`               // `asc` または `desc` 指定が無い場合は `desc`
+	Sort                *string `url:"sort,omitempty"`                // `created`, `updated` または `issueUpdated`
+	Count               *int    `url:"count,omitempty"`               // 取得上限 (1-100) 指定が無い場合は 20
+	Offset              *int    `url:"offset,omitempty"`              // オフセット
+	ResourceAlreadyRead *bool   `url:"resourceAlreadyRead,omitempty"` // 既読のウォッチを含めるか
+	IssueIDs            []int   `url:"issueId[],omitempty"`           // 課題のID
+}
+
+// WatchingCountRequest represents a request to count watchings.
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-watching/
+type WatchingCountRequest struct {
+	ResourceAlreadyRead *bool `url:"resourceAlreadyRead,omitempty"` // 既読のウォッチを含めるか
+	AlreadyRead         *bool `url:"alreadyRead,omitempty"`         // ウォッチ自体が既読かどうか
+}
+
+// List lists watchings of the user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-watching-list/
+func (s *WatchingsService) List(userID int, request WatchingListRequest) ([]*Watching, *Response, error) {
+	u, _ := addOptions(fmt.Sprintf("users/%d/watchings", userID), request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watchings := []*Watching{}
+	resp, err := s.client.Do(req, &watchings)
+	if err != nil {
+		return nil, resp, err
+	}
+	return watchings, resp, nil
+}
+
+// Count returns the number of watchings of the user.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/count-watching/
+func (s *WatchingsService) Count(userID int, request WatchingCountRequest) (int, *Response, error) {
+	u, _ := addOptions(fmt.Sprintf("users/%d/watchings/count", userID), request)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count := new(countResponse)
+	resp, err := s.client.Do(req, &count)
+	if err != nil {
+		return 0, resp, err
+	}
+	return count.Count, resp, nil
+}
+
+// Get a watching.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-watching/
+func (s *WatchingsService) Get(watchingID int) (*Watching, *Response, error) {
+	u := fmt.Sprintf("watchings/%d", watchingID)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watching := new(Watching)
+	resp, err := s.client.Do(req, &watching)
+	if err != nil {
+		return nil, resp, err
+	}
+	return watching, resp, nil
+}
+
+// Add starts watching the issue.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/add-watching/
+func (s *WatchingsService) Add(issueIDOrKey string, note string) (*Watching, *Response, error) {
+	u := "watchings"
+	v := url.Values{}
+	v.Set("issueIdOrKey", issueIDOrKey)
+	if len(note) > 0 {
+		v.Set("note", note)
+	}
+
+	req, err := s.client.NewRequest("POST", u, &v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watching := new(Watching)
+	resp, err := s.client.Do(req, &watching)
+	if err != nil {
+		return nil, resp, err
+	}
+	return watching, resp, nil
+}
+
+// Update updates the note of a watching.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/update-watching/
+func (s *WatchingsService) Update(watchingID int, note string) (*Watching, *Response, error) {
+	u := fmt.Sprintf("watchings/%d", watchingID)
+	v := url.Values{}
+	v.Set("note", note)
+
+	req, err := s.client.NewRequest("PATCH", u, &v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watching := new(Watching)
+	resp, err := s.client.Do(req, &watching)
+	if err != nil {
+		return nil, resp, err
+	}
+	return watching, resp, nil
+}
+
+// Delete stops a watching and returns the deleted watching.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/delete-watching/
+func (s *WatchingsService) Delete(watchingID int) (*Watching, *Response, error) {
+	u := fmt.Sprintf("watchings/%d", watchingID)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watching := new(Watching)
+	resp, err := s.client.Do(req, &watching)
+	if err != nil {
+		return nil, resp, err
+	}
+	return watching, resp, nil
+}
+
+// MarkAsRead marks a watching as read.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/mark-watching-as-read/
+func (s *WatchingsService) MarkAsRead(watchingID int) (*Response, error) {
+	u := fmt.Sprintf("watchings/%d/markAsRead", watchingID)
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Watch starts watching the issue.
+// It is a shorthand for WatchingsService.Add.
+func (s *IssuesService) Watch(issueKey string, note string) (*Watching, *Response, error) {
+	return s.client.Watchings.Add(issueKey, note)
+}
+
+// Unwatch stops the watching of the issue by the user.
+// It returns a nil Watching if the user does not watch the issue.
+func (s *IssuesService) Unwatch(userID int, issueKey string) (*Watching, *Response, error) {
+	issue, resp, err := s.Get(issueKey)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	watchings, resp, err := s.client.Watchings.List(userID, WatchingListRequest{IssueIDs: []int{issue.ID}})
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(watchings) == 0 {
+		return nil, resp, nil
+	}
+	return s.client.Watchings.Delete(watchings[0].ID)
+}
@@ -0,0 +1,160 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestWatchingsService_List(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/users/3/watchings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if got, want := q.Get("sort"), "issueUpdated"; got != want {
+			t.Errorf("sort: %v, want %v", got, want)
+		}
+		if got, want := q["issueId[]"], []string{"10", "11"}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("issueId[]: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"id":5,"resourceAlreadyRead":true,"note":"on call","type":"issue",
+			"issue":{"id":10,"issueKey":"TEST-1","summary":"first issue"}}]`)
+	})
+
+	watchings, _, err := client.Watchings.List(3, WatchingListRequest{Sort: pointers.String("issueUpdated"), IssueIDs: []int{10, 11}})
+	if err != nil {
+		t.Fatalf("Watchings.List returned error: %v", err)
+	}
+	if len(watchings) != 1 {
+		t.Fatalf("Watchings.List returned %d watchings, want 1", len(watchings))
+	}
+	w := watchings[0]
+	if w.ID != 5 || w.Note != "on call" || !w.ResourceAlreadyRead {
+		t.Errorf("Watchings.List returned %+v", w)
+	}
+	if w.Issue == nil || w.Issue.IssueKey != "TEST-1" {
+		t.Errorf("Watching.Issue is %+v, want TEST-1", w.Issue)
+	}
+}
+
+func TestWatchingsService_Count(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/users/3/watchings/count", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if got, want := q.Get("resourceAlreadyRead"), "false"; got != want {
+			t.Errorf("resourceAlreadyRead: %v, want %v", got, want)
+		}
+		if got, want := q.Get("alreadyRead"), "true"; got != want {
+			t.Errorf("alreadyRead: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"count":12}`)
+	})
+
+	count, _, err := client.Watchings.Count(3, WatchingCountRequest{ResourceAlreadyRead: pointers.Bool(false), AlreadyRead: pointers.Bool(true)})
+	if err != nil {
+		t.Fatalf("Watchings.Count returned error: %v", err)
+	}
+	if count != 12 {
+		t.Errorf("Watchings.Count returned %d, want 12", count)
+	}
+}
+
+func TestWatchingsService_AddUpdateDelete(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/watchings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.FormValue("issueIdOrKey"), "TEST-1"; got != want {
+			t.Errorf("issueIdOrKey: %v, want %v", got, want)
+		}
+		if got, want := r.FormValue("note"), "on call"; got != want {
+			t.Errorf("note: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"id":5,"note":"on call"}`)
+	})
+	mux.HandleFunc("/api/v2/watchings/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			if got, want := r.FormValue("note"), "handed over"; got != want {
+				t.Errorf("note: %v, want %v", got, want)
+			}
+			fmt.Fprint(w, `{"id":5,"note":"handed over"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"id":5,"note":"handed over"}`)
+		default:
+			t.Errorf("Request method: %v, want PATCH or DELETE", r.Method)
+		}
+	})
+
+	watching, _, err := client.Watchings.Add("TEST-1", "on call")
+	if err != nil {
+		t.Fatalf("Watchings.Add returned error: %v", err)
+	}
+	if watching.ID != 5 {
+		t.Errorf("Watchings.Add returned %+v", watching)
+	}
+
+	watching, _, err = client.Watchings.Update(5, "handed over")
+	if err != nil {
+		t.Fatalf("Watchings.Update returned error: %v", err)
+	}
+	if watching.Note != "handed over" {
+		t.Errorf("Watchings.Update returned %+v", watching)
+	}
+
+	if _, _, err := client.Watchings.Delete(5); err != nil {
+		t.Errorf("Watchings.Delete returned error: %v", err)
+	}
+}
+
+func TestWatchingsService_MarkAsRead(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/watchings/5/markAsRead", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Watchings.MarkAsRead(5); err != nil {
+		t.Errorf("Watchings.MarkAsRead returned error: %v", err)
+	}
+}
+
+func TestIssuesService_Unwatch(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/issues/TEST-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":10,"issueKey":"TEST-1"}`)
+	})
+	mux.HandleFunc("/api/v2/users/3/watchings", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("issueId[]"), "10"; got != want {
+			t.Errorf("issueId[]: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"id":5}]`)
+	})
+	deleted := false
+	mux.HandleFunc("/api/v2/watchings/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = true
+		fmt.Fprint(w, `{"id":5}`)
+	})
+
+	watching, _, err := client.Issues.Unwatch(3, "TEST-1")
+	if err != nil {
+		t.Fatalf("Issues.Unwatch returned error: %v", err)
+	}
+	if !deleted || watching == nil || watching.ID != 5 {
+		t.Errorf("Issues.Unwatch returned %+v, deleted %v", watching, deleted)
+	}
+}
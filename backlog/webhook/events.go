@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// Event is a webhook event posted by Backlog.
+type Event interface {
+	// Type returns the activity type of the event.
+	Type() backlog.ActivityType
+}
+
+// IssueEvent is posted when an issue is created, updated, commented or deleted.
+type IssueEvent struct {
+	Activity *backlog.Activity
+	Issue    *backlog.Issue
+	Comment  *backlog.IssueComment // nil if the event has no comment
+	Changes  []backlog.ChangeLog
+}
+
+// Type returns the activity type of the event.
+func (e *IssueEvent) Type() backlog.ActivityType { return e.Activity.Type }
+
+// WikiEvent is posted when a wiki page is created, updated or deleted.
+type WikiEvent struct {
+	Activity *backlog.Activity
+	Wiki     *backlog.WikiActivityContent
+}
+
+// Type returns the activity type of the event.
+func (e *WikiEvent) Type() backlog.ActivityType { return e.Activity.Type }
+
+// GitPushEvent is posted when commits are pushed to a Git repository.
+type GitPushEvent struct {
+	Activity *backlog.Activity
+	Push     *backlog.GitPushActivityContent
+}
+
+// Type returns the activity type of the event.
+func (e *GitPushEvent) Type() backlog.ActivityType { return e.Activity.Type }
+
+// PullRequestEvent is posted when a pull request is added, updated, commented or deleted.
+type PullRequestEvent struct {
+	Activity    *backlog.Activity
+	PullRequest *backlog.PullRequestActivityContent
+}
+
+// Type returns the activity type of the event.
+func (e *PullRequestEvent) Type() backlog.ActivityType { return e.Activity.Type }
+
+// ActivityEvent is posted for the other activity types.
+// Activity.Content holds the decoded content, which is a
+// backlog.GenericActivityContent for the types without a struct.
+type ActivityEvent struct {
+	Activity *backlog.Activity
+}
+
+// Type returns the activity type of the event.
+func (e *ActivityEvent) Type() backlog.ActivityType { return e.Activity.Type }
+
+// issueContent is the content of the issue events, which is richer than
+// backlog.IssueActivityContent in the activity API.
+type issueContent struct {
+	backlog.IssueActivityContent
+
+	IssueType  backlog.IssueType  `json:"issueType"`
+	Priority   *backlog.Priority  `json:"priority"`
+	Status     *backlog.Status    `json:"status"`
+	Assignee   *backlog.User      `json:"assignee"`
+	Categories []backlog.Category `json:"category"`
+	Versions   []issueVersion     `json:"versions"`
+	Milestones []issueVersion     `json:"milestone"`
+	StartDate  string             `json:"startDate"`
+	DueDate    string             `json:"dueDate"`
+}
+
+type issueVersion struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Parse decodes a webhook payload into an Event.
+func Parse(payload []byte) (Event, error) {
+	activity := new(backlog.Activity)
+	if err := json.Unmarshal(payload, activity); err != nil {
+		return nil, err
+	}
+
+	switch activity.Type {
+	case backlog.ActivityTypeIssueCreated, backlog.ActivityTypeIssueUpdated,
+		backlog.ActivityTypeIssueCommented, backlog.ActivityTypeIssueDeleted:
+		return newIssueEvent(activity)
+	case backlog.ActivityTypeWikiCreated, backlog.ActivityTypeWikiUpdated, backlog.ActivityTypeWikiDeleted:
+		wiki, _ := activity.Content.(*backlog.WikiActivityContent)
+		return &WikiEvent{Activity: activity, Wiki: wiki}, nil
+	case backlog.ActivityTypeGitPushed, backlog.ActivityTypeGitRepositoryCreated:
+		push, _ := activity.Content.(*backlog.GitPushActivityContent)
+		return &GitPushEvent{Activity: activity, Push: push}, nil
+	case backlog.ActivityTypePullRequestAdded, backlog.ActivityTypePullRequestUpdated,
+		backlog.ActivityTypePullRequestCommented, backlog.ActivityTypePullRequestDeleted:
+		pr, _ := activity.Content.(*backlog.PullRequestActivityContent)
+		return &PullRequestEvent{Activity: activity, PullRequest: pr}, nil
+	}
+	return &ActivityEvent{Activity: activity}, nil
+}
+
+func newIssueEvent(activity *backlog.Activity) (*IssueEvent, error) {
+	content := new(issueContent)
+	if err := json.Unmarshal(activity.RawContent, content); err != nil {
+		return nil, fmt.Errorf("webhook: decoding issue content of activity %d: %v", activity.ID, err)
+	}
+
+	issue := &backlog.Issue{
+		ID:          content.ID,
+		KeyID:       content.KeyID,
+		Summary:     content.Summary,
+		Description: content.Description,
+		IssueType:   content.IssueType,
+		Categories:  content.Categories,
+		Updated:     activity.Created,
+		StartDate:   parseDate(content.StartDate),
+		DueDate:     parseDate(content.DueDate),
+	}
+	if activity.Project != nil {
+		issue.ProjectID = activity.Project.ID
+		issue.IssueKey = activity.Project.ProjectKey + "-" + strconv.Itoa(content.KeyID)
+	}
+	if content.Priority != nil {
+		issue.Priority = *content.Priority
+	}
+	if content.Status != nil {
+		issue.Status.ID = content.Status.ID
+		issue.Status.Name = content.Status.Name
+	}
+	if content.Assignee != nil {
+		issue.Assignee.ID = content.Assignee.ID
+		issue.Assignee.Name = content.Assignee.Name
+	}
+	for _, v := range content.Versions {
+		issue.Versions = append(issue.Versions, backlog.Version{ID: v.ID, Name: v.Name})
+	}
+	for _, v := range content.Milestones {
+		issue.Milestones = append(issue.Milestones, backlog.Version{ID: v.ID, Name: v.Name})
+	}
+
+	event := &IssueEvent{Activity: activity, Issue: issue}
+	if activity.Type == backlog.ActivityTypeIssueCreated {
+		issue.Created = activity.Created
+		issue.CreatedUser.ID = activity.CreatedUser.ID
+		issue.CreatedUser.UserID = activity.CreatedUser.UserID
+		issue.CreatedUser.Name = activity.CreatedUser.Name
+	}
+	if content.Comment != nil && (content.Comment.ID != 0 || len(content.Comment.Content) > 0) {
+		comment := &backlog.IssueComment{
+			ID:      content.Comment.ID,
+			Content: content.Comment.Content,
+			Created: activity.Created,
+			Updated: activity.Created,
+		}
+		comment.CreatedUser.ID = activity.CreatedUser.ID
+		comment.CreatedUser.UserID = activity.CreatedUser.UserID
+		comment.CreatedUser.Name = activity.CreatedUser.Name
+		event.Comment = comment
+	}
+	for _, c := range content.Changes {
+		event.Changes = append(event.Changes, backlog.ChangeLog{
+			Field:         c.Field,
+			NewValue:      c.NewValue,
+			OriginalValue: c.OldValue,
+		})
+	}
+	if event.Comment != nil {
+		event.Comment.ChangeLogs = event.Changes
+	}
+	return event, nil
+}
+
+// parseDate parses the date of the issue content, which is either yyyy-MM-dd or RFC 3339.
+func parseDate(s string) time.Time {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
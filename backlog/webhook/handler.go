@@ -0,0 +1,170 @@
+// Package webhook receives webhooks posted by Backlog and decodes them into typed events.
+package webhook
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+// maxPayloadSize is the maximum size of a webhook payload accepted by Handler.
+const maxPayloadSize = 1 << 20
+
+// Handler is an http.Handler which decodes Backlog webhooks and calls the
+// functions registered for the activity type of the event.
+//
+// Events without registered functions are acknowledged and dropped.
+// If a function returns an error, Handler responds with 500 so that the error
+// shows up in the webhook history of Backlog.
+type Handler struct {
+	// ErrorLog logs errors of the registered functions and bad payloads.
+	// If nil, the log package's standard logger is used.
+	ErrorLog *log.Logger
+
+	mu       sync.RWMutex
+	handlers map[backlog.ActivityType][]func(Event) error
+}
+
+// NewHandler returns a new Handler.
+func NewHandler() *Handler {
+	return &Handler{handlers: map[backlog.ActivityType][]func(Event) error{}}
+}
+
+// HandleFunc registers fn for the activity types.
+func (h *Handler) HandleFunc(fn func(Event) error, types ...backlog.ActivityType) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = map[backlog.ActivityType][]func(Event) error{}
+	}
+	for _, t := range types {
+		h.handlers[t] = append(h.handlers[t], fn)
+	}
+}
+
+// OnIssueCreated registers fn for backlog.ActivityTypeIssueCreated.
+func (h *Handler) OnIssueCreated(fn func(*IssueEvent) error) {
+	h.onIssue(fn, backlog.ActivityTypeIssueCreated)
+}
+
+// OnIssueUpdated registers fn for backlog.ActivityTypeIssueUpdated.
+func (h *Handler) OnIssueUpdated(fn func(*IssueEvent) error) {
+	h.onIssue(fn, backlog.ActivityTypeIssueUpdated)
+}
+
+// OnIssueCommented registers fn for backlog.ActivityTypeIssueCommented.
+func (h *Handler) OnIssueCommented(fn func(*IssueEvent) error) {
+	h.onIssue(fn, backlog.ActivityTypeIssueCommented)
+}
+
+// OnIssueDeleted registers fn for backlog.ActivityTypeIssueDeleted.
+func (h *Handler) OnIssueDeleted(fn func(*IssueEvent) error) {
+	h.onIssue(fn, backlog.ActivityTypeIssueDeleted)
+}
+
+func (h *Handler) onIssue(fn func(*IssueEvent) error, t backlog.ActivityType) {
+	h.HandleFunc(func(e Event) error {
+		ev, ok := e.(*IssueEvent)
+		if !ok {
+			return unexpectedEvent(e, "*IssueEvent")
+		}
+		return fn(ev)
+	}, t)
+}
+
+// OnWiki registers fn for the wiki created, updated and deleted events.
+func (h *Handler) OnWiki(fn func(*WikiEvent) error) {
+	h.HandleFunc(func(e Event) error {
+		ev, ok := e.(*WikiEvent)
+		if !ok {
+			return unexpectedEvent(e, "*WikiEvent")
+		}
+		return fn(ev)
+	}, backlog.ActivityTypeWikiCreated, backlog.ActivityTypeWikiUpdated, backlog.ActivityTypeWikiDeleted)
+}
+
+// OnGitPush registers fn for the Git pushed and repository created events.
+func (h *Handler) OnGitPush(fn func(*GitPushEvent) error) {
+	h.HandleFunc(func(e Event) error {
+		ev, ok := e.(*GitPushEvent)
+		if !ok {
+			return unexpectedEvent(e, "*GitPushEvent")
+		}
+		return fn(ev)
+	}, backlog.ActivityTypeGitPushed, backlog.ActivityTypeGitRepositoryCreated)
+}
+
+// OnPullRequest registers fn for the pull request added, updated, commented and deleted events.
+func (h *Handler) OnPullRequest(fn func(*PullRequestEvent) error) {
+	h.HandleFunc(func(e Event) error {
+		ev, ok := e.(*PullRequestEvent)
+		if !ok {
+			return unexpectedEvent(e, "*PullRequestEvent")
+		}
+		return fn(ev)
+	}, backlog.ActivityTypePullRequestAdded, backlog.ActivityTypePullRequestUpdated,
+		backlog.ActivityTypePullRequestCommented, backlog.ActivityTypePullRequestDeleted)
+}
+
+// unexpectedEvent is the error for an event passed to Dispatch whose Go type
+// does not match its activity type, e.g. an *ActivityEvent of an issue type.
+func unexpectedEvent(e Event, want string) error {
+	return fmt.Errorf("webhook: %v event is %T, want %s", e.Type(), e, want)
+}
+
+// ServeHTTP decodes the webhook payload and dispatches the event.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		h.logf("webhook: reading payload: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	event, err := Parse(payload)
+	if err != nil {
+		h.logf("webhook: decoding payload: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(event); err != nil {
+		h.logf("webhook: handling %v event: %v", event.Type(), err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Dispatch calls the functions registered for the type of event in order,
+// and stops at the first error.
+func (h *Handler) Dispatch(event Event) error {
+	h.mu.RLock()
+	handlers := h.handlers[event.Type()]
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/mnkd/go-backlog/backlog"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func post(h http.Handler, payload []byte) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/backlog", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestParse_issueCreated(t *testing.T) {
+	event, err := Parse(loadFixture(t, "issue_created.json"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	e, ok := event.(*IssueEvent)
+	if !ok {
+		t.Fatalf("Parse returned %T, want *IssueEvent", event)
+	}
+	issue := e.Issue
+	if issue.IssueKey != "WEB-12" || issue.ProjectID != 1 || issue.Summary != "Login fails with SSO" {
+		t.Errorf("Issue is %+v", issue)
+	}
+	if issue.IssueType.Name != "Bug" || issue.Priority.Name != "High" || issue.Status.Name != "Open" || issue.Assignee.Name != "Bob" {
+		t.Errorf("Issue attributes are %+v", issue)
+	}
+	if len(issue.Milestones) != 1 || issue.Milestones[0].Name != "v2.1" {
+		t.Errorf("Issue.Milestones is %+v", issue.Milestones)
+	}
+	if got := issue.DueDate.Format("2006-01-02"); got != "2026-10-15" {
+		t.Errorf("Issue.DueDate is %v", got)
+	}
+	if !issue.StartDate.IsZero() {
+		t.Errorf("Issue.StartDate is %v, want zero", issue.StartDate)
+	}
+	if !issue.Created.Equal(e.Activity.Created) || issue.Created.IsZero() {
+		t.Errorf("Issue.Created is %v, want %v", issue.Created, e.Activity.Created)
+	}
+	if issue.CreatedUser.UserID != "alice" {
+		t.Errorf("Issue.CreatedUser is %+v", issue.CreatedUser)
+	}
+	if e.Comment != nil {
+		t.Errorf("IssueEvent.Comment is %+v, want nil", e.Comment)
+	}
+}
+
+func TestParse_issueUpdated(t *testing.T) {
+	event, err := Parse(loadFixture(t, "issue_updated.json"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	e := event.(*IssueEvent)
+	want := []backlog.ChangeLog{
+		{Field: "status", NewValue: "In Progress", OriginalValue: "Open"},
+		{Field: "assigner", NewValue: "Alice", OriginalValue: "Bob"},
+	}
+	if len(e.Changes) != len(want) {
+		t.Fatalf("IssueEvent.Changes is %+v, want %+v", e.Changes, want)
+	}
+	for i := range want {
+		if e.Changes[i] != want[i] {
+			t.Errorf("IssueEvent.Changes[%d] is %+v, want %+v", i, e.Changes[i], want[i])
+		}
+	}
+	if !e.Issue.Created.IsZero() {
+		t.Errorf("Issue.Created is %v, want zero for an update", e.Issue.Created)
+	}
+	if e.Comment == nil || e.Comment.ID != 501 || e.Comment.CreatedUser.Name != "Alice" || len(e.Comment.ChangeLogs) != 2 {
+		t.Errorf("IssueEvent.Comment is %+v", e.Comment)
+	}
+}
+
+func TestParse_types(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    backlog.ActivityType
+		check   func(Event) bool
+	}{
+		{"issue_commented.json", backlog.ActivityTypeIssueCommented, func(e Event) bool {
+			return e.(*IssueEvent).Comment.Content == "Fixed in WEB-15, deploying today."
+		}},
+		{"issue_deleted.json", backlog.ActivityTypeIssueDeleted, func(e Event) bool {
+			return e.(*IssueEvent).Issue.IssueKey == "WEB-13"
+		}},
+		{"wiki_updated.json", backlog.ActivityTypeWikiUpdated, func(e Event) bool {
+			return e.(*WikiEvent).Wiki.Name == "Release checklist"
+		}},
+		{"git_pushed.json", backlog.ActivityTypeGitPushed, func(e Event) bool {
+			p := e.(*GitPushEvent).Push
+			return p.Ref == "refs/heads/main" && len(p.Revisions) == 2 && p.Revisions[0].Comment == "fixes WEB-12"
+		}},
+		{"pull_request_added.json", backlog.ActivityTypePullRequestAdded, func(e Event) bool {
+			pr := e.(*PullRequestEvent).PullRequest
+			return pr.Number == 9 && pr.Issue != nil && pr.Issue.KeyID == 12
+		}},
+	}
+
+	for _, tt := range tests {
+		event, err := Parse(loadFixture(t, tt.fixture))
+		if err != nil {
+			t.Errorf("Parse(%v) returned error: %v", tt.fixture, err)
+			continue
+		}
+		if got := event.Type(); got != tt.want {
+			t.Errorf("Parse(%v) returned %v event, want %v", tt.fixture, got, tt.want)
+			continue
+		}
+		if !tt.check(event) {
+			t.Errorf("Parse(%v) returned unexpected event %+v", tt.fixture, event)
+		}
+	}
+}
+
+func TestHandler_dispatch(t *testing.T) {
+	h := NewHandler()
+
+	var created, updated []*IssueEvent
+	var pushes []*GitPushEvent
+	h.OnIssueCreated(func(e *IssueEvent) error { created = append(created, e); return nil })
+	h.OnIssueUpdated(func(e *IssueEvent) error { updated = append(updated, e); return nil })
+	h.OnGitPush(func(e *GitPushEvent) error { pushes = append(pushes, e); return nil })
+
+	for _, name := range []string{"issue_created.json", "issue_updated.json", "git_pushed.json", "wiki_updated.json"} {
+		if w := post(h, loadFixture(t, name)); w.Code != http.StatusOK {
+			t.Errorf("POST %v returned %d, want %d", name, w.Code, http.StatusOK)
+		}
+	}
+
+	if len(created) != 1 || len(updated) != 1 || len(pushes) != 1 {
+		t.Errorf("dispatched created:%d updated:%d pushes:%d, want 1 each", len(created), len(updated), len(pushes))
+	}
+}
+
+func TestHandler_errors(t *testing.T) {
+	h := NewHandler()
+	h.ErrorLog = log.New(ioutil.Discard, "", 0)
+	h.OnWiki(func(e *WikiEvent) error { return errors.New("boom") })
+
+	r := httptest.NewRequest("GET", "/backlog", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET returned %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+
+	if w := post(h, []byte(`{"type":`)); w.Code != http.StatusBadRequest {
+		t.Errorf("POST with a broken payload returned %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	if w := post(h, loadFixture(t, "wiki_updated.json")); w.Code != http.StatusInternalServerError {
+		t.Errorf("POST with a failing handler returned %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestHandler_dispatchUnexpectedEvent(t *testing.T) {
+	h := NewHandler()
+	h.OnIssueUpdated(func(e *IssueEvent) error { return nil })
+	h.OnWiki(func(e *WikiEvent) error { return nil })
+
+	for _, typ := range []backlog.ActivityType{backlog.ActivityTypeIssueUpdated, backlog.ActivityTypeWikiCreated} {
+		event := &ActivityEvent{Activity: &backlog.Activity{Type: typ}}
+		if err := h.Dispatch(event); err == nil {
+			t.Errorf("Dispatch of *ActivityEvent with %v returned nil error", typ)
+		}
+	}
+}
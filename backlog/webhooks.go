@@ -0,0 +1,143 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook is Backlog webhook in the Backlog project
+type Webhook struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	HookURL         string    `json:"hookUrl"`
+	AllEvent        bool      `json:"allEvent"`
+	ActivityTypeIDs []int     `json:"activityTypeIds"`
+	CreatedUser     User      `json:"createdUser"`
+	Created         time.Time `json:"created"`
+	UpdatedUser     User      `json:"updatedUser"`
+	Updated         time.Time `json:"updated"`
+}
+
+// WebhookRequest represents a request to create/update a webhook.
+type WebhookRequest struct {
+	Name            *string
+	Description     *string
+	HookURL         *string
+	AllEvent        *bool
+	ActivityTypeIDs []int // ActivityType
+}
+
+// ListWebhooks lists all webhooks in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-list-of-webhooks/
+func (s *ProjectsService) ListWebhooks(projectKey string) ([]*Webhook, *Response, error) {
+	u := "projects/" + projectKey + "/webhooks"
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhooks := []*Webhook{}
+	resp, err := s.client.Do(req, &webhooks)
+	if err != nil {
+		return nil, resp, err
+	}
+	return webhooks, resp, nil
+}
+
+// GetWebhook gets a webhook in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/get-webhook/
+func (s *ProjectsService) GetWebhook(projectKey string, webhookID int) (*Webhook, *Response, error) {
+	u := fmt.Sprintf("projects/%s/webhooks/%d", projectKey, webhookID)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook := new(Webhook)
+	resp, err := s.client.Do(req, &webhook)
+	if err != nil {
+		return nil, resp, err
+	}
+	return webhook, resp, nil
+}
+
+// CreateWebhook creates a new webhook in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/add-webhook/
+func (s *ProjectsService) CreateWebhook(projectKey string, request WebhookRequest) (*Webhook, *Response, error) {
+	u := "projects/" + projectKey + "/webhooks"
+	v := request.makeValues()
+	req, err := s.client.NewRequest("POST", u, &v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook := new(Webhook)
+	resp, err := s.client.Do(req, &webhook)
+	if err != nil {
+		return nil, resp, err
+	}
+	return webhook, resp, nil
+}
+
+// UpdateWebhook updates a webhook in the project.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/update-webhook/
+func (s *ProjectsService) UpdateWebhook(projectKey string, webhookID int, request WebhookRequest) (*Webhook, *Response, error) {
+	u := fmt.Sprintf("projects/%s/webhooks/%d", projectKey, webhookID)
+	v := request.makeValues()
+	req, err := s.client.NewRequest("PATCH", u, &v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook := new(Webhook)
+	resp, err := s.client.Do(req, &webhook)
+	if err != nil {
+		return nil, resp, err
+	}
+	return webhook, resp, nil
+}
+
+// DeleteWebhook deletes a webhook in the project and returns the deleted webhook.
+//
+// https://developer.nulab-inc.com/ja/docs/backlog/api/2/delete-webhook/
+func (s *ProjectsService) DeleteWebhook(projectKey string, webhookID int) (*Webhook, *Response, error) {
+	u := fmt.Sprintf("projects/%s/webhooks/%d", projectKey, webhookID)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook := new(Webhook)
+	resp, err := s.client.Do(req, &webhook)
+	if err != nil {
+		return nil, resp, err
+	}
+	return webhook, resp, nil
+}
+
+func (r WebhookRequest) makeValues() url.Values {
+	v := url.Values{}
+	if r.Name != nil {
+		v.Set("name", *r.Name)
+	}
+	if r.Description != nil {
+		v.Set("description", *r.Description)
+	}
+	if r.HookURL != nil {
+		v.Set("hookUrl", *r.HookURL)
+	}
+	if r.AllEvent != nil {
+		v.Set("allEvent", fmt.Sprintf("%t", *r.AllEvent))
+	}
+	for _, id := range r.ActivityTypeIDs {
+		v.Add("activityTypeIds[]", fmt.Sprintf("%d", id))
+	}
+
+	return v
+}
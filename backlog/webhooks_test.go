@@ -0,0 +1,133 @@
+package backlog
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	pointers "github.com/f2prateek/go-pointers"
+)
+
+func TestProjectsService_ListWebhooks(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/TEST/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":3,"name":"deploy","hookUrl":"https://example.com/hook","allEvent":false,"activityTypeIds":[1,2]}]`)
+	})
+
+	webhooks, _, err := client.Projects.ListWebhooks("TEST")
+	if err != nil {
+		t.Fatalf("Projects.ListWebhooks returned error: %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].ID != 3 || fmt.Sprint(webhooks[0].ActivityTypeIDs) != "[1 2]" {
+		t.Errorf("Projects.ListWebhooks returned %+v", webhooks)
+	}
+}
+
+func TestProjectsService_GetWebhook(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/TEST/webhooks/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":3,"name":"deploy","hookUrl":"https://example.com/hook"}`)
+	})
+
+	webhook, _, err := client.Projects.GetWebhook("TEST", 3)
+	if err != nil {
+		t.Fatalf("Projects.GetWebhook returned error: %v", err)
+	}
+	if webhook.ID != 3 || webhook.HookURL != "https://example.com/hook" {
+		t.Errorf("Projects.GetWebhook returned %+v", webhook)
+	}
+}
+
+func TestProjectsService_CreateWebhook(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/TEST/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"name":        "deploy",
+			"description": "notify deploys",
+			"hookUrl":     "https://example.com/hook",
+			"allEvent":    "false",
+		}
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("%s: %v, want %v", k, got, v)
+			}
+		}
+		if got, want := r.PostForm["activityTypeIds[]"], []string{"1", "2"}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("activityTypeIds[]: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"id":3,"name":"deploy"}`)
+	})
+
+	webhook, _, err := client.Projects.CreateWebhook("TEST", WebhookRequest{
+		Name:            pointers.String("deploy"),
+		Description:     pointers.String("notify deploys"),
+		HookURL:         pointers.String("https://example.com/hook"),
+		AllEvent:        pointers.Bool(false),
+		ActivityTypeIDs: []int{int(ActivityTypeIssueCreated), int(ActivityTypeIssueUpdated)},
+	})
+	if err != nil {
+		t.Fatalf("Projects.CreateWebhook returned error: %v", err)
+	}
+	if webhook.ID != 3 {
+		t.Errorf("Projects.CreateWebhook returned %+v", webhook)
+	}
+}
+
+func TestProjectsService_UpdateWebhook(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/TEST/webhooks/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := r.PostForm.Get("allEvent"), "true"; got != want {
+			t.Errorf("allEvent: %v, want %v", got, want)
+		}
+		for _, k := range []string{"name", "description", "hookUrl", "activityTypeIds[]"} {
+			if _, ok := r.PostForm[k]; ok {
+				t.Errorf("%s is sent, want omitted", k)
+			}
+		}
+		fmt.Fprint(w, `{"id":3,"allEvent":true}`)
+	})
+
+	webhook, _, err := client.Projects.UpdateWebhook("TEST", 3, WebhookRequest{AllEvent: pointers.Bool(true)})
+	if err != nil {
+		t.Fatalf("Projects.UpdateWebhook returned error: %v", err)
+	}
+	if !webhook.AllEvent {
+		t.Errorf("Projects.UpdateWebhook returned %+v", webhook)
+	}
+}
+
+func TestProjectsService_DeleteWebhook(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/projects/TEST/webhooks/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"id":3,"name":"deploy"}`)
+	})
+
+	webhook, _, err := client.Projects.DeleteWebhook("TEST", 3)
+	if err != nil {
+		t.Fatalf("Projects.DeleteWebhook returned error: %v", err)
+	}
+	if webhook.ID != 3 {
+		t.Errorf("Projects.DeleteWebhook returned %+v", webhook)
+	}
+}
@@ -31,46 +31,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	keys := make([]string, 0, len(projects))
+	for _, project := range projects {
+		keys = append(keys, project.ProjectKey)
+	}
+	metadata := client.Projects.GetMetadata(keys, &backlog.BulkOptions{Workers: 8})
+
 	for i, project := range projects {
 		fmt.Printf("%v. %v (%v)\n", i+1, project.Name, project.ProjectKey)
 
-		issueTypes, _, err := client.Projects.ListIssueTypes(project.ProjectKey)
-		if err != nil {
+		m := metadata[i]
+		for _, err := range m.Errs {
 			fmt.Printf("Error: %v\n", err)
 		}
 
 		fmt.Printf("IssueTypes: \n")
-		for _, issueType := range issueTypes {
+		for _, issueType := range m.IssueTypes {
 			fmt.Printf("  %v (%v)\n", issueType.Name, issueType.Color)
 		}
 
-		categories, _, err := client.Projects.ListCategories(project.ProjectKey)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
-
 		fmt.Printf("Categories: \n")
-		for _, category := range categories {
+		for _, category := range m.Categories {
 			fmt.Printf("  %v\n", category.Name)
 		}
 
-		versions, _, err := client.Projects.ListVersions(project.ProjectKey)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
-
 		fmt.Printf("Versions: \n")
-		for _, version := range versions {
+		for _, version := range m.Versions {
 			fmt.Printf("  %v\n", version.Name)
 		}
 
-		users, _, err := client.Projects.ListUsers(project.ProjectKey)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
-
 		fmt.Printf("Users: \n")
-		for _, user := range users {
+		for _, user := range m.Users {
 			fmt.Printf("  %v\n", user.Name)
 		}
 	}
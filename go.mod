@@ -1 +1,3 @@
 module github.com/mnkd/go-backlog
+
+go 1.21